@@ -0,0 +1,13 @@
+data "restapi_graphql_query" "admins" {
+  query = <<-EOT
+    query ($role: String!) {
+      users(role: $role) { id name }
+    }
+  EOT
+
+  variables = jsonencode({
+    role = "admin"
+  })
+
+  result_path = "users"
+}
@@ -1,5 +1,21 @@
 data "restapi_object" "John" {
-  path         = "/api/objects"
-  search_key   = "first"
-  search_value = "John"
+  path = "/api/objects"
+
+  read_search = {
+    search_key   = "first"
+    search_value = "John"
+  }
+}
+
+data "restapi_object" "Jane" {
+  path      = "/api/objects"
+  object_id = "42"
+}
+
+data "restapi_object" "client" {
+  path      = "/api/clients"
+  object_id = "web"
+
+  # Masked in api_response, available as sensitive_values["credentials/secret"].
+  sensitive_keys = ["credentials/secret"]
 }
@@ -0,0 +1,25 @@
+data "restapi_response" "version" {
+  path = "/api/version"
+}
+
+data "restapi_response" "zones" {
+  path = "/api/dns/zones"
+
+  query_params = {
+    limit = "100"
+  }
+
+  headers = {
+    Accept = "application/json"
+  }
+
+  response_key = "items"
+}
+
+output "version" {
+  value = jsondecode(data.restapi_response.version.response_data).version
+}
+
+output "zone_names" {
+  value = [for zone in jsondecode(data.restapi_response.zones.response_data) : zone.name]
+}
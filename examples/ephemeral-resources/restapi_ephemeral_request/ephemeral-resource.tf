@@ -0,0 +1,39 @@
+variable "password" {
+  type      = string
+  ephemeral = true
+}
+
+ephemeral "restapi_ephemeral_request" "login" {
+  path = "/api/login"
+
+  body = jsonencode({
+    username = "terraform"
+    password = var.password
+  })
+
+  response_key = "session/token"
+
+  # Keep the session alive during long running operations.
+  renew = {
+    path     = "/api/sessions/{response.session/id}/refresh"
+    interval = 600
+  }
+
+  # Log out at the end of the Terraform run.
+  close = {
+    path = "/api/sessions/{response.session/id}"
+
+    headers = {
+      Authorization = "Bearer {response.session/token}"
+    }
+  }
+}
+
+provider "restapi" {
+  alias    = "session"
+  endpoint = "https://api.example.com"
+
+  headers = {
+    Authorization = "Bearer ${jsondecode(ephemeral.restapi_ephemeral_request.login.response_data)}"
+  }
+}
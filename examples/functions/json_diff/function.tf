@@ -0,0 +1,3 @@
+output "user_drift" {
+  value = provider::restapi::json_diff(restapi_object.user.data, restapi_object.user.api_response)
+}
@@ -0,0 +1,3 @@
+output "user_summary" {
+  value = provider::restapi::json_filter(restapi_object.user.api_response, ["id", "name"], true)
+}
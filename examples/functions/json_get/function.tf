@@ -0,0 +1,3 @@
+output "first_zone_name" {
+  value = provider::restapi::json_get(data.restapi_response.zones.response_body, "items/0/name")
+}
@@ -0,0 +1,13 @@
+resource "restapi_object" "user" {
+  path = "/api/users"
+
+  data = provider::restapi::json_merge(
+    file("${path.module}/defaults.json"),
+    jsonencode({
+      name = "Foo"
+      settings = {
+        theme = "dark"
+      }
+    })
+  )
+}
@@ -0,0 +1,11 @@
+resource "restapi_object" "user" {
+  path = "/api/users"
+
+  data = provider::restapi::json_patch(
+    data.restapi_response.template.response_body,
+    jsonencode({
+      name     = "Foo"
+      internal = null
+    })
+  )
+}
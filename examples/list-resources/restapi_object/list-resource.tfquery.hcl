@@ -0,0 +1,11 @@
+list "restapi_object" "users" {
+  provider = restapi
+
+  config {
+    path         = "/api/users"
+    query_string = "per_page=100"
+    result_key   = "items"
+    next_key     = "links/next"
+    data_keys    = ["name", "email"]
+  }
+}
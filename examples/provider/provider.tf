@@ -7,6 +7,9 @@ provider "restapi" {
     "Content-Type" = "application/json"
   }
 
+  # Redact the token from the debug logs.
+  sensitive_headers = ["X-Auth-Token"]
+
   create_method  = "POST"
   update_method  = "PUT"
   destroy_method = "DELETE"
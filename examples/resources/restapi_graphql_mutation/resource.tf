@@ -0,0 +1,39 @@
+resource "restapi_graphql_mutation" "user" {
+  create_mutation = <<-EOT
+    mutation ($name: String!, $email: String!) {
+      createUser(input: { name: $name, email: $email }) {
+        user { id name email }
+      }
+    }
+  EOT
+
+  read_query = <<-EOT
+    query ($id: ID!) {
+      user(id: $id) { id name email }
+    }
+  EOT
+
+  update_mutation = <<-EOT
+    mutation ($id: ID!, $name: String!, $email: String!) {
+      updateUser(id: $id, input: { name: $name, email: $email }) {
+        user { id name email }
+      }
+    }
+  EOT
+
+  delete_mutation = <<-EOT
+    mutation ($id: ID!) {
+      deleteUser(id: $id)
+    }
+  EOT
+
+  variables = jsonencode({
+    name  = "Foo"
+    email = "foo@example.com"
+  })
+
+  id_path            = "createUser/user/id"
+  create_result_path = "createUser/user"
+  read_result_path   = "user"
+  update_result_path = "updateUser/user"
+}
@@ -0,0 +1,18 @@
+resource "restapi_jsonrpc_object" "vlan" {
+  path = "/api/jsonrpc"
+
+  data = jsonencode({
+    vid  = 100
+    name = "servers"
+  })
+
+  create_method = "vlan.create"
+  read_method   = "vlan.get"
+  update_method = "vlan.update"
+  delete_method = "vlan.delete"
+
+  # Positional params with the object ID and the data.
+  update_params = jsonencode(["{id}", "{data}"])
+
+  id_path = "vlan/id"
+}
@@ -0,0 +1,8 @@
+import {
+  to = restapi_object.foo
+
+  identity = {
+    path      = "/api/objects"
+    object_id = "123"
+  }
+}
@@ -0,0 +1,16 @@
+# Import an object by its API path and ID.
+terraform import restapi_object.foo /api/objects/123
+
+# Import an object with an arbitrary ID, e.g. containing slashes, by separating it from the API path with `|`.
+terraform import restapi_object.file '/api/files|path/to/file.txt'
+
+# Import an object with an extended import ID, passed as key/value pairs with URL-encoded values or as JSON object.
+# Supported keys are `path`, `id`, `read_path`, `id_attribute`, `id_attributes`, `id_separator`, `query_string`,
+# `read_search` and `data_keys`. As key/value pairs, `read_search` is passed as `search_key`, `search_value`,
+# `result_key` and `search_query_string`, and `id_attributes` and `data_keys` as comma-separated lists.
+# The data is populated from the keys in `data_keys` of the object, or the whole object filtered by `response_filter`.
+terraform import restapi_object.thing 'path=/api/things&id=abc&read_path=/api/things/{id}/details&id_attribute=uuid&data_keys=name,size'
+terraform import restapi_object.user '{"path": "/api/users", "id": "42", "read_search": {"search_key": "name", "search_value": "foo"}}'
+
+# Import an object with a composite ID (see `id_attributes`) by passing the joined ID and its attributes.
+terraform import restapi_object.team 'path=/api/teams&id=acme:devs&id_attributes=org,slug&read_path=/orgs/{id.org}/teams/{id.slug}'
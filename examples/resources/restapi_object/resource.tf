@@ -3,3 +3,16 @@ resource "restapi_object" "Foo2" {
   path     = "/api/objects"
   data     = "{ \"id\": \"55555\", \"first\": \"Foo\", \"last\": \"Bar\" }"
 }
+
+resource "restapi_object" "user" {
+  path = "/api/users"
+  data = jsonencode({
+    name = "foo"
+  })
+
+  # Never stored in the state, bump the version to rotate the password.
+  secret_data = jsonencode({
+    password = var.user_password
+  })
+  secret_data_version = 1
+}
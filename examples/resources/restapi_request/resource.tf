@@ -0,0 +1,24 @@
+resource "restapi_request" "reindex" {
+  path = "/api/search/reindex"
+
+  body = jsonencode({
+    indices = ["users", "groups"]
+  })
+
+  expected_status_codes = [200, 202]
+
+  # Send the request again whenever the schema version changes.
+  triggers = {
+    schema_version = "3"
+  }
+}
+
+resource "restapi_request" "api_key" {
+  path = "/api/keys"
+
+  body = jsonencode({
+    name = "ci"
+  })
+
+  destroy_path = "/api/keys/ci"
+}
@@ -0,0 +1,485 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restrequest"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+const (
+	ephemeralRenewKey = "renew"
+	ephemeralCloseKey = "close"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ ephemeral.EphemeralResource                   = &EphemeralRequestResource{}
+	_ ephemeral.EphemeralResourceWithConfigure      = &EphemeralRequestResource{}
+	_ ephemeral.EphemeralResourceWithValidateConfig = &EphemeralRequestResource{}
+	_ ephemeral.EphemeralResourceWithRenew          = &EphemeralRequestResource{}
+	_ ephemeral.EphemeralResourceWithClose          = &EphemeralRequestResource{}
+)
+
+func NewEphemeralRequestResource() ephemeral.EphemeralResource {
+	return &EphemeralRequestResource{}
+}
+
+// EphemeralRequestResource defines the ephemeral resource implementation.
+type EphemeralRequestResource struct {
+	client *restclient.RestClient
+}
+
+type EphemeralRequestResourceModel struct {
+	Method              types.String `tfsdk:"method"`
+	Path                types.String `tfsdk:"path"`
+	QueryParams         types.Map    `tfsdk:"query_params"`
+	Body                types.String `tfsdk:"body"`
+	ContentType         types.String `tfsdk:"content_type"`
+	Headers             types.Map    `tfsdk:"headers"`
+	ExpectedStatusCodes types.List   `tfsdk:"expected_status_codes"`
+	ResponseFormat      types.String `tfsdk:"response_format"`
+	ResponseKey         types.String `tfsdk:"response_key"`
+	Renew               types.Object `tfsdk:"renew"`
+	Close               types.Object `tfsdk:"close"`
+	StatusCode          types.Int64  `tfsdk:"status_code"`
+	ResponseHeaders     types.Map    `tfsdk:"response_headers"`
+	ResponseBody        types.String `tfsdk:"response_body"`
+	ResponseData        types.String `tfsdk:"response_data"`
+}
+
+type EphemeralFollowUpRequest struct {
+	Method  types.String `tfsdk:"method"`
+	Path    types.String `tfsdk:"path"`
+	Body    types.String `tfsdk:"body"`
+	Headers types.Map    `tfsdk:"headers"`
+}
+
+type EphemeralRenewRequest struct {
+	EphemeralFollowUpRequest
+
+	Interval types.Int64 `tfsdk:"interval"`
+}
+
+// ephemeralFollowUp is the private data of a renew or close request. The
+// request is stored with the response placeholders already expanded.
+type ephemeralFollowUp struct {
+	Request  *restrequest.RequestOptions `json:"request"`
+	Interval int64                       `json:"interval,omitempty"`
+}
+
+func (r *EphemeralRequestResource) Metadata(
+	_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_ephemeral_request"
+}
+
+func (r *EphemeralRequestResource) Schema(
+	_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse,
+) {
+	followUpAttributes := func(method string) map[string]schema.Attribute {
+		return map[string]schema.Attribute{
+			"method": schema.StringAttribute{
+				Description: fmt.Sprintf("Defaults to `%s`. The HTTP method of the request.", method),
+				Optional:    true,
+			},
+			"path": schema.StringAttribute{
+				Description: "The API path of the request in addition to the base URL defined in the provider configuration.",
+				Required:    true,
+			},
+			"body": schema.StringAttribute{
+				Description: "The body of the request.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Description: "Headers of the request, which take precedence over the `headers` defined " +
+					"in the provider configuration.",
+				Optional:  true,
+				Sensitive: true,
+			},
+		}
+	}
+
+	renewAttributes := followUpAttributes(http.MethodPost)
+	renewAttributes["interval"] = schema.Int64Attribute{
+		Description: "Interval in seconds after which Terraform renews the ephemeral resource, " +
+			"if it is still in use by a long running operation. Must be at least `1`.",
+		Required: true,
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Restapi ephemeral request resource schema. Sends a request, e.g. to log in to an API, " +
+			"and exposes the response without persisting it in the plan or state. The placeholders " +
+			"`{response.<key>}` in the `path`, `body` and `headers` of `renew` and `close` are replaced by " +
+			"the value at the slash-delimited key path in the decoded response body, e.g. " +
+			"`Bearer {response.session/token}`. Values are escaped as JSON string content in JSON bodies " +
+			"and URL-escaped in the `path` and form bodies.",
+
+		Attributes: map[string]schema.Attribute{
+			"method": schema.StringAttribute{
+				Description: "Defaults to `POST`. The HTTP method of the request.",
+				Optional:    true,
+			},
+			"path": schema.StringAttribute{
+				Description: "The API path of the request in addition to the base URL defined in the provider configuration.",
+				Required:    true,
+			},
+			"query_params": schema.MapAttribute{
+				ElementType: types.StringType,
+				Description: "Query parameters added to the query string of the request.",
+				Optional:    true,
+			},
+			"body": schema.StringAttribute{
+				Description: "The body of the request, e.g. the credentials to log in.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"content_type": schema.StringAttribute{
+				Description: "The content type of the request body. Defaults to `application/json` if `body` is set.",
+				Optional:    true,
+			},
+			"headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Description: "Headers of the request, which take precedence over the `headers` defined " +
+					"in the provider configuration.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"expected_status_codes": schema.ListAttribute{
+				ElementType: types.Int64Type,
+				Description: "Status codes of successful responses. Defaults to all `2xx` status codes. " +
+					"Responses with other status codes fail the request.",
+				Optional: true,
+			},
+			"response_format": schema.StringAttribute{
+				Description: "Defaults to `response_format` defined in the provider configuration. " +
+					"Allows override of `response_format` (see `response_format` provider documentation).",
+				Optional: true,
+			},
+			"response_key": schema.StringAttribute{
+				Description: "Slash-delimited key path of the value in the decoded response body that is exposed " +
+					"as `response_data`, e.g. `session/token`. Defaults to the whole body.",
+				Optional: true,
+			},
+			"renew": schema.SingleNestedAttribute{
+				Description: "Request to renew the ephemeral resource, e.g. to keep a session alive. " +
+					"The response of the renew request does not change the exposed response.",
+				Optional:   true,
+				Attributes: renewAttributes,
+			},
+			"close": schema.SingleNestedAttribute{
+				Description: "Request to close the ephemeral resource at the end of the Terraform run, e.g. to log out.",
+				Optional:    true,
+				Attributes:  followUpAttributes(http.MethodDelete),
+			},
+			"status_code": schema.Int64Attribute{
+				Description: "The status code of the response.",
+				Computed:    true,
+			},
+			"response_headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Description: "The headers of the response. Multiple values of a header are joined by `, `.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"response_body": schema.StringAttribute{
+				Description: "The raw body of the response.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"response_data": schema.StringAttribute{
+				Description: "JSON encoded value of the decoded response body at `response_key`, " +
+					"usable with `jsondecode`.",
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func (r *EphemeralRequestResource) Configure(
+	_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*restclient.RestClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *http.Client, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *EphemeralRequestResource) ValidateConfig(
+	ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse,
+) {
+	var interval types.Int64
+
+	intervalPath := path.Root("renew").AtName("interval")
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, intervalPath, &interval)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Unknown values might be set later.
+	if interval.IsNull() || interval.IsUnknown() {
+		return
+	}
+
+	if interval.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			intervalPath,
+			"Invalid Attribute Value",
+			fmt.Sprintf("`renew.interval` must be at least 1 second, got: %d.", interval.ValueInt64()),
+		)
+	}
+}
+
+func (r *EphemeralRequestResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data EphemeralRequestResourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := &restrequest.RequestOptions{
+		Method:      http.MethodPost,
+		Path:        data.Path.ValueString(),
+		Body:        data.Body.ValueString(),
+		ContentType: data.ContentType.ValueString(),
+	}
+
+	if !data.Method.IsNull() && !data.Method.IsUnknown() {
+		opts.Method = data.Method.ValueString()
+	}
+
+	if !data.QueryParams.IsNull() && !data.QueryParams.IsUnknown() {
+		resp.Diagnostics.Append(data.QueryParams.ElementsAs(ctx, &opts.QueryParams, false)...)
+	}
+
+	if !data.Headers.IsNull() && !data.Headers.IsUnknown() {
+		resp.Diagnostics.Append(data.Headers.ElementsAs(ctx, &opts.Headers, false)...)
+	}
+
+	if !data.ExpectedStatusCodes.IsNull() && !data.ExpectedStatusCodes.IsUnknown() {
+		resp.Diagnostics.Append(data.ExpectedStatusCodes.ElementsAs(ctx, &opts.ExpectedStatusCodes, false)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiResp, err := restrequest.Send(ctx, r.client, opts)
+	if err != nil {
+		resp.Diagnostics.AddError("Can not send request", err.Error())
+
+		return
+	}
+
+	format := data.ResponseFormat.ValueString()
+	if format == "" {
+		format = r.client.Options.ResponseFormat
+	}
+
+	responseBody, err := restrequest.DecodeResponse(r.client, apiResp, format, "")
+	if err != nil {
+		resp.Diagnostics.AddError("Can not decode response", err.Error())
+
+		return
+	}
+
+	responseData, err := restrequest.DecodeResponse(r.client, apiResp, format, data.ResponseKey.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Can not decode response", err.Error())
+
+		return
+	}
+
+	data.StatusCode = types.Int64Value(int64(apiResp.StatusCode))
+	data.ResponseBody = types.StringValue(apiResp.Body)
+	data.ResponseData = types.StringValue(responseData)
+
+	headers, diags := mapResponseHeaders(ctx, apiResp.Header)
+	resp.Diagnostics.Append(diags...)
+	data.ResponseHeaders = headers
+
+	asOpts := basetypes.ObjectAsOptions{UnhandledNullAsEmpty: true, UnhandledUnknownAsEmpty: true}
+
+	if !data.Renew.IsNull() && !data.Renew.IsUnknown() {
+		var renew EphemeralRenewRequest
+
+		resp.Diagnostics.Append(data.Renew.As(ctx, &renew, asOpts)...)
+
+		renewReq, diags := toFollowUpRequest(ctx, renew.EphemeralFollowUpRequest, http.MethodPost, responseBody)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		followUp := &ephemeralFollowUp{Request: renewReq, Interval: renew.Interval.ValueInt64()}
+
+		resp.RenewAt = time.Now().Add(time.Duration(followUp.Interval) * time.Second)
+		resp.Diagnostics.Append(setFollowUpRequest(ctx, resp.Private, ephemeralRenewKey, followUp)...)
+	}
+
+	if !data.Close.IsNull() && !data.Close.IsUnknown() {
+		var closeConfig EphemeralFollowUpRequest
+
+		resp.Diagnostics.Append(data.Close.As(ctx, &closeConfig, asOpts)...)
+
+		closeReq, diags := toFollowUpRequest(ctx, closeConfig, http.MethodDelete, responseBody)
+		resp.Diagnostics.Append(diags...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		followUp := &ephemeralFollowUp{Request: closeReq}
+
+		resp.Diagnostics.Append(setFollowUpRequest(ctx, resp.Private, ephemeralCloseKey, followUp)...)
+	}
+
+	// Save data into ephemeral result data
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (r *EphemeralRequestResource) Renew(
+	ctx context.Context, req ephemeral.RenewRequest, resp *ephemeral.RenewResponse,
+) {
+	renew, diags := getFollowUpRequest(ctx, req.Private, ephemeralRenewKey)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() || renew == nil {
+		return
+	}
+
+	if _, err := restrequest.Send(ctx, r.client, renew.Request); err != nil {
+		resp.Diagnostics.AddError("Can not renew ephemeral request", err.Error())
+
+		return
+	}
+
+	resp.RenewAt = time.Now().Add(time.Duration(renew.Interval) * time.Second)
+}
+
+func (r *EphemeralRequestResource) Close(
+	ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse,
+) {
+	closeReq, diags := getFollowUpRequest(ctx, req.Private, ephemeralCloseKey)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() || closeReq == nil {
+		return
+	}
+
+	if _, err := restrequest.Send(ctx, r.client, closeReq.Request); err != nil {
+		resp.Diagnostics.AddError("Can not close ephemeral request", err.Error())
+	}
+}
+
+// toFollowUpRequest builds the renew or close request from its configuration
+// and expands the response placeholders with the decoded response body.
+func toFollowUpRequest(
+	ctx context.Context, followUp EphemeralFollowUpRequest, method, response string,
+) (*restrequest.RequestOptions, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	opts := &restrequest.RequestOptions{
+		Method: method,
+		Path:   followUp.Path.ValueString(),
+		Body:   followUp.Body.ValueString(),
+	}
+
+	if !followUp.Method.IsNull() && !followUp.Method.IsUnknown() {
+		opts.Method = followUp.Method.ValueString()
+	}
+
+	if !followUp.Headers.IsNull() && !followUp.Headers.IsUnknown() {
+		diags.Append(followUp.Headers.ElementsAs(ctx, &opts.Headers, false)...)
+	}
+
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	opts, err := restrequest.ExpandResponse(opts, response)
+	if err != nil {
+		diags.AddError("Can not expand request", err.Error())
+
+		return nil, diags
+	}
+
+	return opts, diags
+}
+
+// privateData is implemented by the private data of resources and ephemeral resources.
+type privateData interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// setFollowUpRequest stores the renew or close request in the private data.
+func setFollowUpRequest(
+	ctx context.Context, private privateData, key string, followUp *ephemeralFollowUp,
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	value, err := json.Marshal(followUp)
+	if err != nil {
+		diags.AddError("Can not store ephemeral request", err.Error())
+
+		return diags
+	}
+
+	return private.SetKey(ctx, key, value)
+}
+
+// getFollowUpRequest returns the renew or close request stored in the private
+// data, or nil if there is none.
+func getFollowUpRequest(ctx context.Context, private privateData, key string) (*ephemeralFollowUp, diag.Diagnostics) {
+	var followUp ephemeralFollowUp
+
+	value, diags := private.GetKey(ctx, key)
+	if diags.HasError() || len(value) == 0 {
+		return nil, diags
+	}
+
+	if err := json.Unmarshal(value, &followUp); err != nil {
+		diags.AddError("Can not read ephemeral request", err.Error())
+
+		return nil, diags
+	}
+
+	return &followUp, diags
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEphemeralRequestValidateRenewInterval(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval int64
+		wantErr  bool
+	}{
+		{
+			name:     "valid interval",
+			interval: 600,
+		},
+		{
+			name:     "zero interval",
+			interval: 0,
+			wantErr:  true,
+		},
+		{
+			name:     "negative interval",
+			interval: -1,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, "https://restapi.local")
+			schema := server.schemas.EphemeralResourceSchemas["restapi_ephemeral_request"]
+
+			typ, ok := schema.ValueType().(tftypes.Object)
+			if !ok {
+				t.Fatal("unexpected schema type")
+			}
+
+			renewType, ok := typ.AttributeTypes["renew"].(tftypes.Object)
+			if !ok {
+				t.Fatal("unexpected renew type")
+			}
+
+			renew, err := newDynamicValue(t, renewType, map[string]tftypes.Value{
+				"path":     tftypes.NewValue(tftypes.String, "/renew"),
+				"interval": tftypes.NewValue(tftypes.Number, tt.interval),
+			}).Unmarshal(renewType)
+			assert.NoError(t, err)
+
+			resp, err := server.ValidateEphemeralResourceConfig(t.Context(),
+				&tfprotov6.ValidateEphemeralResourceConfigRequest{
+					TypeName: "restapi_ephemeral_request",
+					Config: newDynamicValue(t, typ, map[string]tftypes.Value{
+						"path":  tftypes.NewValue(tftypes.String, "/login"),
+						"renew": renew,
+					}),
+				})
+			assert.NoError(t, err)
+
+			if tt.wantErr {
+				assert.Len(t, resp.Diagnostics, 1)
+				assert.Contains(t, resp.Diagnostics[0].Detail, "`renew.interval` must be at least 1 second")
+
+				return
+			}
+
+			assertNoDiagnostics(t, resp.Diagnostics)
+		})
+	}
+}
+
+func TestEphemeralRequestValidateNoRenew(t *testing.T) {
+	server := newTestServer(t, "https://restapi.local")
+
+	resp, err := server.ValidateEphemeralResourceConfig(t.Context(), &tfprotov6.ValidateEphemeralResourceConfigRequest{
+		TypeName: "restapi_ephemeral_request",
+		Config: server.dynamicValue(t, server.schemas.EphemeralResourceSchemas["restapi_ephemeral_request"],
+			map[string]tftypes.Value{"path": tftypes.NewValue(tftypes.String, "/login")}),
+	})
+	assert.NoError(t, err)
+	assertNoDiagnostics(t, resp.Diagnostics)
+}
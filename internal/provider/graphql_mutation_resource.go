@@ -0,0 +1,393 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/graphql"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &GraphqlMutationResource{}
+
+func NewGraphqlMutationResource() resource.Resource {
+	return &GraphqlMutationResource{}
+}
+
+// GraphqlMutationResource defines the resource implementation.
+type GraphqlMutationResource struct {
+	client *restclient.RestClient
+}
+
+type GraphqlMutationResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Path             types.String `tfsdk:"path"`
+	CreateMutation   types.String `tfsdk:"create_mutation"`
+	ReadQuery        types.String `tfsdk:"read_query"`
+	UpdateMutation   types.String `tfsdk:"update_mutation"`
+	DeleteMutation   types.String `tfsdk:"delete_mutation"`
+	Variables        types.String `tfsdk:"variables"`
+	IDPath           types.String `tfsdk:"id_path"`
+	IDVariable       types.String `tfsdk:"id_variable"`
+	CreateResultPath types.String `tfsdk:"create_result_path"`
+	ReadResultPath   types.String `tfsdk:"read_result_path"`
+	UpdateResultPath types.String `tfsdk:"update_result_path"`
+	Result           types.String `tfsdk:"result"`
+	ResponseRaw      types.String `tfsdk:"response_raw"`
+}
+
+func (r *GraphqlMutationResource) Metadata(
+	_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_graphql_mutation"
+}
+
+func (r *GraphqlMutationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// Consider data sensitive if env variables is set to true.
+	isDataSensitive, _ := strconv.ParseBool(utils.GetEnvOrDefault("RESTAPI_SENSITIVE_DATA", "false"))
+
+	resp.Schema = schema.Schema{
+		Description: "Restapi GraphQL mutation resource schema. Manages an object through GraphQL mutations " +
+			"sent to the endpoint defined in the provider configuration.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the object as gathered from `id_path`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"path": schema.StringAttribute{
+				Description: "Defaults to `/graphql`. The API path of the GraphQL endpoint in addition to the base URL " +
+					"defined in the provider configuration.",
+				Optional: true,
+			},
+			"create_mutation": schema.StringAttribute{
+				Description: "GraphQL mutation to create the object. It receives `variables`.",
+				Required:    true,
+			},
+			"read_query": schema.StringAttribute{
+				Description: "GraphQL query to read the object. It receives `variables` and the object ID as `id_variable`. " +
+					"If the result at `read_result_path` is null, the object is removed from the state. " +
+					"If not set, the result of the last mutation is kept.",
+				Optional: true,
+			},
+			"update_mutation": schema.StringAttribute{
+				Description: "GraphQL mutation to update the object. It receives `variables` and the object ID as " +
+					"`id_variable`. If not set, changes of `variables` replace the object.",
+				Optional: true,
+			},
+			"delete_mutation": schema.StringAttribute{
+				Description: "GraphQL mutation to delete the object. It receives `variables` and the object ID as " +
+					"`id_variable`. If not set, the object is only removed from the state.",
+				Optional: true,
+			},
+			"variables": schema.StringAttribute{
+				Description: "JSON object of the variables passed to all operations.",
+				Required:    true,
+				Sensitive:   isDataSensitive,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						func(
+							ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse,
+						) {
+							var updateMutation types.String
+
+							resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("update_mutation"), &updateMutation)...)
+							resp.RequiresReplace = updateMutation.IsNull()
+						},
+						"Changes replace the object if no `update_mutation` is set.",
+						"Changes replace the object if no `update_mutation` is set.",
+					),
+				},
+			},
+			"id_path": schema.StringAttribute{
+				Description: "Slash-delimited path to the object ID in the `data` of the create mutation response, " +
+					"e.g. `createUser/user/id`.",
+				Required: true,
+			},
+			"id_variable": schema.StringAttribute{
+				Description: "Defaults to `id`. Name of the variable that holds the object ID in the read query, " +
+					"update mutation and delete mutation.",
+				Optional: true,
+			},
+			"create_result_path": schema.StringAttribute{
+				Description: "Slash-delimited path to the object in the `data` of the create mutation response, " +
+					"e.g. `createUser/user`. Defaults to the whole `data` object.",
+				Optional: true,
+			},
+			"read_result_path": schema.StringAttribute{
+				Description: "Slash-delimited path to the object in the `data` of the read query response, " +
+					"e.g. `user`. Defaults to the whole `data` object.",
+				Optional: true,
+			},
+			"update_result_path": schema.StringAttribute{
+				Description: "Slash-delimited path to the object in the `data` of the update mutation response, " +
+					"e.g. `updateUser/user`. Defaults to the whole `data` object.",
+				Optional: true,
+			},
+			"result": schema.StringAttribute{
+				Description: "JSON encoded object as extracted from the result path of the last response.",
+				Computed:    true,
+				Sensitive:   isDataSensitive,
+			},
+			"response_raw": schema.StringAttribute{
+				Description: "The raw body of the last GraphQL response.",
+				Computed:    true,
+				Sensitive:   isDataSensitive,
+			},
+		},
+	}
+}
+
+func (r *GraphqlMutationResource) Configure(
+	_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*restclient.RestClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *restapi.Client, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *GraphqlMutationResource) Create(
+	ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse,
+) {
+	var data GraphqlMutationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, diags := r.newMutation(data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := m.Create(ctx); err != nil {
+		resp.Diagnostics.Append(graphqlDiagnostics("Client Error", err)...)
+
+		return
+	}
+
+	resp.Diagnostics.Append(mapGraphqlMutationFields(m.Options, &data)...)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *GraphqlMutationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data GraphqlMutationResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, diags := r.newMutation(data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := m.Read(ctx); err != nil {
+		resp.Diagnostics.Append(graphqlDiagnostics("Client Error", err)...)
+
+		return
+	}
+
+	// The object ID is removed if the object does not exist (anymore).
+	if m.Options.ID == "" {
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	resp.Diagnostics.Append(mapGraphqlMutationFields(m.Options, &data)...)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *GraphqlMutationResource) Update(
+	ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse,
+) {
+	var data, state GraphqlMutationResourceModel
+
+	// Read Terraform plan and prior state data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, diags := r.newMutation(data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the result of the last response if there is no update mutation.
+	resp.Diagnostics.Append(decodeJSONAttribute(state.Result, &m.Options.Result)...)
+	m.Options.ResponseRaw = state.ResponseRaw.ValueString()
+
+	if err := m.Update(ctx); err != nil {
+		resp.Diagnostics.Append(graphqlDiagnostics("Client Error", err)...)
+
+		return
+	}
+
+	resp.Diagnostics.Append(mapGraphqlMutationFields(m.Options, &data)...)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *GraphqlMutationResource) Delete(
+	ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse,
+) {
+	var data GraphqlMutationResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	m, diags := r.newMutation(data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := m.Delete(ctx); err != nil {
+		resp.Diagnostics.Append(graphqlDiagnostics("Client Error", err)...)
+	}
+}
+
+func (r *GraphqlMutationResource) newMutation(data GraphqlMutationResourceModel) (*graphql.Mutation, diag.Diagnostics) {
+	opts := &graphql.MutationOptions{
+		Path:             data.Path.ValueString(),
+		CreateMutation:   data.CreateMutation.ValueString(),
+		ReadQuery:        data.ReadQuery.ValueString(),
+		UpdateMutation:   data.UpdateMutation.ValueString(),
+		DeleteMutation:   data.DeleteMutation.ValueString(),
+		IDPath:           data.IDPath.ValueString(),
+		IDVariable:       data.IDVariable.ValueString(),
+		CreateResultPath: data.CreateResultPath.ValueString(),
+		ReadResultPath:   data.ReadResultPath.ValueString(),
+		UpdateResultPath: data.UpdateResultPath.ValueString(),
+		ID:               data.ID.ValueString(),
+	}
+
+	diags := decodeJSONAttribute(data.Variables, &opts.Variables)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if !data.Result.IsNull() && !data.Result.IsUnknown() {
+		diags.Append(decodeJSONAttribute(data.Result, &opts.Result)...)
+	}
+
+	opts.ResponseRaw = data.ResponseRaw.ValueString()
+
+	m, err := graphql.NewMutation(r.client, opts)
+	if err != nil {
+		diags.AddError("Failed to create API client", err.Error())
+	}
+
+	return m, diags
+}
+
+func mapGraphqlMutationFields(opts *graphql.MutationOptions, model *GraphqlMutationResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(opts.ID)
+
+	result, err := json.Marshal(opts.Result)
+	if err != nil {
+		diags.AddError("Can not map fields", fmt.Sprintf("%s: %v", err, opts.Result))
+	}
+
+	model.Result = types.StringValue(string(result))
+	model.ResponseRaw = types.StringValue(opts.ResponseRaw)
+
+	return diags
+}
+
+// decodeJSONAttribute decodes the JSON value of a string attribute if it is known.
+func decodeJSONAttribute(value types.String, target any) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if value.IsNull() || value.IsUnknown() {
+		return diags
+	}
+
+	if err := json.Unmarshal([]byte(value.ValueString()), target); err != nil {
+		diags.AddError("Can not parse attribute", fmt.Sprintf("%s: %v", err, value))
+	}
+
+	return diags
+}
+
+// graphqlDiagnostics returns a diagnostic for each GraphQL error in the given
+// error, or a single diagnostic for other errors.
+func graphqlDiagnostics(summary string, err error) diag.Diagnostics {
+	var (
+		diags     diag.Diagnostics
+		gqlErrors graphql.Errors
+	)
+
+	if !errors.As(err, &gqlErrors) {
+		diags.AddError(summary, err.Error())
+
+		return diags
+	}
+
+	for _, gqlErr := range gqlErrors {
+		diags.AddError("GraphQL Error", gqlErr.Error())
+	}
+
+	return diags
+}
@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/graphql"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &GraphqlQueryDataSource{}
+
+func NewGraphqlQueryDataSource() datasource.DataSource {
+	return &GraphqlQueryDataSource{}
+}
+
+// GraphqlQueryDataSource defines the data source implementation.
+type GraphqlQueryDataSource struct {
+	client *restclient.RestClient
+}
+
+type GraphqlQueryDataSourceModel struct {
+	Path        types.String `tfsdk:"path"`
+	Query       types.String `tfsdk:"query"`
+	Variables   types.String `tfsdk:"variables"`
+	ResultPath  types.String `tfsdk:"result_path"`
+	Result      types.String `tfsdk:"result"`
+	ResponseRaw types.String `tfsdk:"response_raw"`
+}
+
+func (d *GraphqlQueryDataSource) Metadata(
+	_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_graphql_query"
+}
+
+func (d *GraphqlQueryDataSource) Schema(
+	_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse,
+) {
+	// Consider data sensitive if env variables is set to true.
+	isDataSensitive, _ := strconv.ParseBool(utils.GetEnvOrDefault("RESTAPI_SENSITIVE_DATA", "false"))
+
+	resp.Schema = schema.Schema{
+		Description: "Restapi GraphQL query data source schema. Sends a GraphQL query to the endpoint " +
+			"defined in the provider configuration.",
+
+		Attributes: map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				Description: "Defaults to `/graphql`. The API path of the GraphQL endpoint in addition to the base URL " +
+					"defined in the provider configuration.",
+				Optional: true,
+			},
+			"query": schema.StringAttribute{
+				Description: "GraphQL query to send.",
+				Required:    true,
+			},
+			"variables": schema.StringAttribute{
+				Description: "JSON object of the variables passed to the query.",
+				Optional:    true,
+				Sensitive:   isDataSensitive,
+			},
+			"result_path": schema.StringAttribute{
+				Description: "Slash-delimited path to the result in the `data` of the response, e.g. `users/0`. " +
+					"Defaults to the whole `data` object.",
+				Optional: true,
+			},
+			"result": schema.StringAttribute{
+				Description: "JSON encoded result as extracted from `result_path`.",
+				Computed:    true,
+				Sensitive:   isDataSensitive,
+			},
+			"response_raw": schema.StringAttribute{
+				Description: "The raw body of the GraphQL response.",
+				Computed:    true,
+				Sensitive:   isDataSensitive,
+			},
+		},
+	}
+}
+
+func (d *GraphqlQueryDataSource) Configure(
+	_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*restclient.RestClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *http.Client, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *GraphqlQueryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data GraphqlQueryDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := &graphql.QueryOptions{
+		Path:       data.Path.ValueString(),
+		Query:      data.Query.ValueString(),
+		ResultPath: data.ResultPath.ValueString(),
+	}
+
+	resp.Diagnostics.Append(decodeJSONAttribute(data.Variables, &opts.Variables)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	q, err := graphql.NewQuery(d.client, opts)
+	if err != nil {
+		resp.Diagnostics.AddError("Can not create query", err.Error())
+
+		return
+	}
+
+	if err := q.Read(ctx); err != nil {
+		resp.Diagnostics.Append(graphqlDiagnostics("Can not read query", err)...)
+
+		return
+	}
+
+	result, err := json.Marshal(opts.Result)
+	if err != nil {
+		resp.Diagnostics.AddError("Can not map fields", fmt.Sprintf("%s: %v", err, opts.Result))
+
+		return
+	}
+
+	data.Result = types.StringValue(string(result))
+	data.ResponseRaw = types.StringValue(opts.ResponseRaw)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
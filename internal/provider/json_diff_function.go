@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &JSONDiffFunction{}
+
+func NewJSONDiffFunction() function.Function {
+	return &JSONDiffFunction{}
+}
+
+// JSONDiffFunction defines the function implementation.
+type JSONDiffFunction struct{}
+
+func (f *JSONDiffFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "json_diff"
+}
+
+func (f *JSONDiffFunction) Definition(
+	_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Compare a desired JSON object with the actual object",
+		Description: "Returns the JSON merge patch as defined in RFC 7386 that transforms `desired` into " +
+			"the actual object, using the same semantics as the drift detection of `restapi_object`. " +
+			"Keys that only exist in `actual` are ignored and keys that are missing in `actual` are " +
+			"set to `null`. An empty object `{}` is returned if there is no drift.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "desired",
+				Description: "The desired JSON object, e.g. the `data` of a `restapi_object`.",
+			},
+			function.StringParameter{
+				Name:        "actual",
+				Description: "The actual JSON object, e.g. the `api_response` of a `restapi_object`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *JSONDiffFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var desired, actual string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &desired, &actual))
+
+	if resp.Error != nil {
+		return
+	}
+
+	desiredMap, funcErr := decodeJSONObjectArgument(0, desired)
+	if funcErr != nil {
+		resp.Error = funcErr
+
+		return
+	}
+
+	actualMap, funcErr := decodeJSONObjectArgument(1, actual)
+	if funcErr != nil {
+		resp.Error = funcErr
+
+		return
+	}
+
+	diff := utils.DiffMaps(desiredMap, utils.IntersectMaps(desiredMap, actualMap))
+
+	result, funcErr := encodeJSONResult(diff)
+	if funcErr != nil {
+		resp.Error = funcErr
+
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &JSONFilterFunction{}
+
+func NewJSONFilterFunction() function.Function {
+	return &JSONFilterFunction{}
+}
+
+// JSONFilterFunction defines the function implementation.
+type JSONFilterFunction struct{}
+
+func (f *JSONFilterFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "json_filter"
+}
+
+func (f *JSONFilterFunction) Definition(
+	_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Filter the top-level keys of a JSON object",
+		Description: "Returns the JSON object filtered by the top-level keys, using the same semantics " +
+			"as the `response_filter` provider option. If `include` is true, only the keys are kept, " +
+			"otherwise the keys are removed. An empty list of keys returns the object unchanged.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "json",
+				Description: "The JSON object to filter.",
+			},
+			function.ListParameter{
+				ElementType: types.StringType,
+				Name:        "keys",
+				Description: "The top-level keys to filter.",
+			},
+			function.BoolParameter{
+				Name:        "include",
+				Description: "Whether to keep only the keys instead of removing them.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *JSONFilterFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var (
+		data    string
+		keys    []string
+		include bool
+	)
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &data, &keys, &include))
+
+	if resp.Error != nil {
+		return
+	}
+
+	if _, funcErr := decodeJSONObjectArgument(0, data); funcErr != nil {
+		resp.Error = funcErr
+
+		return
+	}
+
+	_, result, err := utils.FilterJSONString(data, keys, include)
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Can not filter JSON: %s", err))
+
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
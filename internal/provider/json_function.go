@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var ErrUnsupportedJSONValue = errors.New("unsupported JSON value")
+
+// decodeJSONArgument decodes the JSON string of a function argument. Numbers
+// are kept as json.Number to retain their precision.
+func decodeJSONArgument(position int64, data string) (any, *function.FuncError) {
+	var value any
+
+	decoder := json.NewDecoder(bytes.NewBufferString(data))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&value); err != nil {
+		return nil, function.NewArgumentFuncError(position, fmt.Sprintf("Invalid JSON: %s", err))
+	}
+
+	return value, nil
+}
+
+// decodeJSONObjectArgument decodes the JSON string of a function argument
+// that is required to be a JSON object.
+func decodeJSONObjectArgument(position int64, data string) (map[string]any, *function.FuncError) {
+	value, funcErr := decodeJSONArgument(position, data)
+	if funcErr != nil {
+		return nil, funcErr
+	}
+
+	obj, ok := value.(map[string]any)
+	if !ok {
+		return nil, function.NewArgumentFuncError(position, "Invalid JSON: value must be an object")
+	}
+
+	return obj, nil
+}
+
+// encodeJSONResult encodes the value as JSON string result of a function.
+func encodeJSONResult(value any) (string, *function.FuncError) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", function.NewFuncError(fmt.Sprintf("Can not encode JSON: %s", err))
+	}
+
+	return string(data), nil
+}
+
+// toDynamicValue converts a decoded JSON value to the framework value that
+// jsondecode would return for it. Objects are converted to object values and
+// arrays to tuple values, nested null values are returned as null strings.
+func toDynamicValue(ctx context.Context, value any) (attr.Value, error) {
+	switch v := value.(type) {
+	case nil:
+		return types.StringNull(), nil
+	case string:
+		return types.StringValue(v), nil
+	case bool:
+		return types.BoolValue(v), nil
+	case json.Number:
+		f, _, err := big.ParseFloat(v.String(), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return nil, err
+		}
+
+		return types.NumberValue(f), nil
+	case float64:
+		return types.NumberValue(big.NewFloat(v)), nil
+	case map[string]any:
+		attrTypes := make(map[string]attr.Type, len(v))
+		attrValues := make(map[string]attr.Value, len(v))
+
+		for key, elem := range v {
+			elemValue, err := toDynamicValue(ctx, elem)
+			if err != nil {
+				return nil, err
+			}
+
+			attrTypes[key] = elemValue.Type(ctx)
+			attrValues[key] = elemValue
+		}
+
+		obj, diags := types.ObjectValue(attrTypes, attrValues)
+		if diags.HasError() {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedJSONValue, diags[0].Detail())
+		}
+
+		return obj, nil
+	case []any:
+		elemTypes := make([]attr.Type, 0, len(v))
+		elemValues := make([]attr.Value, 0, len(v))
+
+		for i, elem := range v {
+			elemValue, err := toDynamicValue(ctx, elem)
+			if err != nil {
+				return nil, fmt.Errorf("index %d: %w", i, err)
+			}
+
+			elemTypes = append(elemTypes, elemValue.Type(ctx))
+			elemValues = append(elemValues, elemValue)
+		}
+
+		tuple, diags := types.TupleValue(elemTypes, elemValues)
+		if diags.HasError() {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedJSONValue, diags[0].Detail())
+		}
+
+		return tuple, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedJSONValue, value)
+	}
+}
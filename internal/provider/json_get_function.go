@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &JSONGetFunction{}
+
+func NewJSONGetFunction() function.Function {
+	return &JSONGetFunction{}
+}
+
+// JSONGetFunction defines the function implementation.
+type JSONGetFunction struct{}
+
+func (f *JSONGetFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "json_get"
+}
+
+func (f *JSONGetFunction) Definition(
+	_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Get the value at a key path of a JSON document",
+		Description: "Returns the value at the slash-delimited key path of the JSON document, e.g. `a/b/0`, " +
+			"using the same path semantics as `id_attribute` and `response_key`. Array elements are addressed " +
+			"by their index. An empty path returns the whole document. The value is returned as `jsondecode` " +
+			"would return it.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "json",
+				Description: "The JSON document.",
+			},
+			function.StringParameter{
+				Name:        "path",
+				Description: "Slash-delimited key path of the value.",
+			},
+		},
+		Return: function.DynamicReturn{},
+	}
+}
+
+func (f *JSONGetFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var data, path string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &data, &path))
+
+	if resp.Error != nil {
+		return
+	}
+
+	value, funcErr := decodeJSONArgument(0, data)
+	if funcErr != nil {
+		resp.Error = funcErr
+
+		return
+	}
+
+	if utils.SanitizePath(path) != "" {
+		container, ok := value.(map[string]any)
+
+		if list, isList := value.([]any); isList {
+			container, ok = utils.IndexList(list), true
+		}
+
+		if !ok {
+			resp.Error = function.NewArgumentFuncError(0, "Invalid JSON: value must be an object or array")
+
+			return
+		}
+
+		var err error
+
+		value, err = utils.GetObjectAtKey(container, path)
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(1, err.Error())
+
+			return
+		}
+	}
+
+	if value == nil {
+		resp.Error = resp.Result.Set(ctx, types.DynamicNull())
+
+		return
+	}
+
+	result, err := toDynamicValue(ctx, value)
+	if err != nil {
+		resp.Error = function.NewFuncError(fmt.Sprintf("Can not convert value: %s", err))
+
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, types.DynamicValue(result))
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &JSONMergeFunction{}
+
+func NewJSONMergeFunction() function.Function {
+	return &JSONMergeFunction{}
+}
+
+// JSONMergeFunction defines the function implementation.
+type JSONMergeFunction struct{}
+
+func (f *JSONMergeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "json_merge"
+}
+
+func (f *JSONMergeFunction) Definition(
+	_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Deep merge two JSON objects",
+		Description: "Returns the JSON object with the keys of both objects. Nested objects are merged " +
+			"recursively, all other values of `overlay` replace the values of `base`, including arrays and `null`.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "base",
+				Description: "The JSON object to merge into.",
+			},
+			function.StringParameter{
+				Name:        "overlay",
+				Description: "The JSON object whose values take precedence.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *JSONMergeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var base, overlay string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &base, &overlay))
+
+	if resp.Error != nil {
+		return
+	}
+
+	baseMap, funcErr := decodeJSONObjectArgument(0, base)
+	if funcErr != nil {
+		resp.Error = funcErr
+
+		return
+	}
+
+	overlayMap, funcErr := decodeJSONObjectArgument(1, overlay)
+	if funcErr != nil {
+		resp.Error = funcErr
+
+		return
+	}
+
+	result, funcErr := encodeJSONResult(utils.MergeMaps(baseMap, overlayMap))
+	if funcErr != nil {
+		resp.Error = funcErr
+
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
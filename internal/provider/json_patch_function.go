@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &JSONPatchFunction{}
+
+func NewJSONPatchFunction() function.Function {
+	return &JSONPatchFunction{}
+}
+
+// JSONPatchFunction defines the function implementation.
+type JSONPatchFunction struct{}
+
+func (f *JSONPatchFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "json_patch"
+}
+
+func (f *JSONPatchFunction) Definition(
+	_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse,
+) {
+	resp.Definition = function.Definition{
+		Summary: "Apply a JSON merge patch to a JSON object",
+		Description: "Returns the JSON object with the JSON merge patch as defined in RFC 7386 applied. " +
+			"Keys with `null` values in the patch are removed, nested objects are patched recursively " +
+			"and all other values are replaced.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "json",
+				Description: "The JSON object to patch.",
+			},
+			function.StringParameter{
+				Name:        "patch",
+				Description: "The JSON merge patch.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *JSONPatchFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var data, patch string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &data, &patch))
+
+	if resp.Error != nil {
+		return
+	}
+
+	dataMap, funcErr := decodeJSONObjectArgument(0, data)
+	if funcErr != nil {
+		resp.Error = funcErr
+
+		return
+	}
+
+	patchMap, funcErr := decodeJSONObjectArgument(1, patch)
+	if funcErr != nil {
+		resp.Error = funcErr
+
+		return
+	}
+
+	result, funcErr := encodeJSONResult(utils.MergePatch(dataMap, patchMap))
+	if funcErr != nil {
+		resp.Error = funcErr
+
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
@@ -0,0 +1,375 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/jsonrpc"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &JSONRPCObjectResource{}
+
+func NewJSONRPCObjectResource() resource.Resource {
+	return &JSONRPCObjectResource{}
+}
+
+// JSONRPCObjectResource defines the resource implementation.
+type JSONRPCObjectResource struct {
+	client *restclient.RestClient
+}
+
+type JSONRPCObjectResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Path         types.String `tfsdk:"path"`
+	Data         types.String `tfsdk:"data"`
+	CreateMethod types.String `tfsdk:"create_method"`
+	CreateParams types.String `tfsdk:"create_params"`
+	ReadMethod   types.String `tfsdk:"read_method"`
+	ReadParams   types.String `tfsdk:"read_params"`
+	UpdateMethod types.String `tfsdk:"update_method"`
+	UpdateParams types.String `tfsdk:"update_params"`
+	DeleteMethod types.String `tfsdk:"delete_method"`
+	DeleteParams types.String `tfsdk:"delete_params"`
+	IDPath       types.String `tfsdk:"id_path"`
+	Result       types.String `tfsdk:"result"`
+	ResponseRaw  types.String `tfsdk:"response_raw"`
+}
+
+func (r *JSONRPCObjectResource) Metadata(
+	_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_jsonrpc_object"
+}
+
+func (r *JSONRPCObjectResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// Consider data sensitive if env variables is set to true.
+	isDataSensitive, _ := strconv.ParseBool(utils.GetEnvOrDefault("RESTAPI_SENSITIVE_DATA", "false"))
+
+	paramsDescription := func(method, defaults string) string {
+		return fmt.Sprintf("JSON params template of the `%s` call. Defaults to %s. ", method, defaults) +
+			"See `create_params` for the supported placeholders."
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Restapi JSON-RPC object resource schema. Manages an object through JSON-RPC 2.0 calls, " +
+			"which are sent as `POST` requests to the same path.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "ID of the object as gathered from the result of the `create_method` call.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"path": schema.StringAttribute{
+				Description: "The API path of the JSON-RPC endpoint in addition to the base URL defined " +
+					"in the provider configuration.",
+				Optional: true,
+			},
+			"data": schema.StringAttribute{
+				Description: "JSON object of the object data that is available to the params templates.",
+				Required:    true,
+				Sensitive:   isDataSensitive,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						func(
+							ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse,
+						) {
+							var updateMethod types.String
+
+							resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("update_method"), &updateMethod)...)
+							resp.RequiresReplace = updateMethod.IsNull()
+						},
+						"Changes replace the object if no `update_method` is set.",
+						"Changes replace the object if no `update_method` is set.",
+					),
+				},
+			},
+			"create_method": schema.StringAttribute{
+				Description: "RPC method to create the object.",
+				Required:    true,
+			},
+			"create_params": schema.StringAttribute{
+				Description: "JSON params template of the `create_method` call. Defaults to `data`. " +
+					"Strings consisting of a single placeholder `{id}`, `{data}` or `{data.<key>}` are replaced by the " +
+					"object ID, the `data` object or the value at the slash-delimited key path in `data`, keeping its type. " +
+					"Placeholders within longer strings are replaced by the formatted value.",
+				Optional: true,
+			},
+			"read_method": schema.StringAttribute{
+				Description: "RPC method to read the object. If the result is null, the object is removed from the state. " +
+					"If not set, the result of the last call is kept.",
+				Optional: true,
+			},
+			"read_params": schema.StringAttribute{
+				Description: paramsDescription("read_method", "`{\"id\": \"{id}\"}`"),
+				Optional:    true,
+			},
+			"update_method": schema.StringAttribute{
+				Description: "RPC method to update the object. If not set, changes of `data` replace the object.",
+				Optional:    true,
+			},
+			"update_params": schema.StringAttribute{
+				Description: paramsDescription("update_method", "`data` with the object ID as key `id`"),
+				Optional:    true,
+			},
+			"delete_method": schema.StringAttribute{
+				Description: "RPC method to delete the object. If not set, the object is only removed from the state.",
+				Optional:    true,
+			},
+			"delete_params": schema.StringAttribute{
+				Description: paramsDescription("delete_method", "`{\"id\": \"{id}\"}`"),
+				Optional:    true,
+			},
+			"id_path": schema.StringAttribute{
+				Description: "Slash-delimited path to the object ID in the result of the `create_method` call, " +
+					"e.g. `user/id`. If not set, the result itself is used if it is a string or number, " +
+					"and its `id` key otherwise.",
+				Optional: true,
+			},
+			"result": schema.StringAttribute{
+				Description: "JSON encoded result of the last call.",
+				Computed:    true,
+				Sensitive:   isDataSensitive,
+			},
+			"response_raw": schema.StringAttribute{
+				Description: "The raw body of the last JSON-RPC response.",
+				Computed:    true,
+				Sensitive:   isDataSensitive,
+			},
+		},
+	}
+}
+
+func (r *JSONRPCObjectResource) Configure(
+	_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*restclient.RestClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *restapi.Client, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *JSONRPCObjectResource) Create(
+	ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse,
+) {
+	var data JSONRPCObjectResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	o, diags := r.newObject(data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := o.Create(ctx); err != nil {
+		resp.Diagnostics.Append(jsonrpcDiagnostics("Client Error", err)...)
+
+		return
+	}
+
+	resp.Diagnostics.Append(mapJSONRPCObjectFields(o.Options, &data)...)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *JSONRPCObjectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data JSONRPCObjectResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	o, diags := r.newObject(data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := o.Read(ctx); err != nil {
+		resp.Diagnostics.Append(jsonrpcDiagnostics("Client Error", err)...)
+
+		return
+	}
+
+	// The object ID is removed if the object does not exist (anymore).
+	if o.Options.ID == "" {
+		resp.State.RemoveResource(ctx)
+
+		return
+	}
+
+	resp.Diagnostics.Append(mapJSONRPCObjectFields(o.Options, &data)...)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *JSONRPCObjectResource) Update(
+	ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse,
+) {
+	var data, state JSONRPCObjectResourceModel
+
+	// Read Terraform plan and prior state data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	o, diags := r.newObject(data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the result of the last response if there is no update method.
+	resp.Diagnostics.Append(decodeJSONAttribute(state.Result, &o.Options.Result)...)
+	o.Options.ResponseRaw = state.ResponseRaw.ValueString()
+
+	if err := o.Update(ctx); err != nil {
+		resp.Diagnostics.Append(jsonrpcDiagnostics("Client Error", err)...)
+
+		return
+	}
+
+	resp.Diagnostics.Append(mapJSONRPCObjectFields(o.Options, &data)...)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *JSONRPCObjectResource) Delete(
+	ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse,
+) {
+	var data JSONRPCObjectResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	o, diags := r.newObject(data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := o.Delete(ctx); err != nil {
+		resp.Diagnostics.Append(jsonrpcDiagnostics("Client Error", err)...)
+	}
+}
+
+func (r *JSONRPCObjectResource) newObject(data JSONRPCObjectResourceModel) (*jsonrpc.Object, diag.Diagnostics) {
+	opts := &jsonrpc.ObjectOptions{
+		Path:         data.Path.ValueString(),
+		CreateMethod: data.CreateMethod.ValueString(),
+		ReadMethod:   data.ReadMethod.ValueString(),
+		UpdateMethod: data.UpdateMethod.ValueString(),
+		DeleteMethod: data.DeleteMethod.ValueString(),
+		IDPath:       data.IDPath.ValueString(),
+		ID:           data.ID.ValueString(),
+		ResponseRaw:  data.ResponseRaw.ValueString(),
+	}
+
+	diags := decodeJSONAttribute(data.Data, &opts.Data)
+	diags.Append(decodeJSONAttribute(data.CreateParams, &opts.CreateParams)...)
+	diags.Append(decodeJSONAttribute(data.ReadParams, &opts.ReadParams)...)
+	diags.Append(decodeJSONAttribute(data.UpdateParams, &opts.UpdateParams)...)
+	diags.Append(decodeJSONAttribute(data.DeleteParams, &opts.DeleteParams)...)
+	diags.Append(decodeJSONAttribute(data.Result, &opts.Result)...)
+
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	o, err := jsonrpc.NewObject(r.client, opts)
+	if err != nil {
+		diags.AddError("Failed to create API client", err.Error())
+	}
+
+	return o, diags
+}
+
+func mapJSONRPCObjectFields(opts *jsonrpc.ObjectOptions, model *JSONRPCObjectResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model.ID = types.StringValue(opts.ID)
+
+	result, err := json.Marshal(opts.Result)
+	if err != nil {
+		diags.AddError("Can not map fields", fmt.Sprintf("%s: %v", err, opts.Result))
+	}
+
+	model.Result = types.StringValue(string(result))
+	model.ResponseRaw = types.StringValue(opts.ResponseRaw)
+
+	return diags
+}
+
+// jsonrpcDiagnostics returns a diagnostic for the JSON-RPC error object in the
+// given error, or a generic diagnostic for other errors.
+func jsonrpcDiagnostics(summary string, err error) diag.Diagnostics {
+	var (
+		diags  diag.Diagnostics
+		rpcErr *jsonrpc.Error
+	)
+
+	if errors.As(err, &rpcErr) {
+		diags.AddError("JSON-RPC Error", err.Error())
+
+		return diags
+	}
+
+	diags.AddError(summary, err.Error())
+
+	return diags
+}
@@ -8,6 +8,9 @@ import (
 	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -16,7 +19,12 @@ import (
 )
 
 // Ensure RestapiProvider satisfies various provider interfaces.
-var _ provider.Provider = &RestapiProvider{}
+var (
+	_ provider.Provider                       = &RestapiProvider{}
+	_ provider.ProviderWithFunctions          = &RestapiProvider{}
+	_ provider.ProviderWithListResources      = &RestapiProvider{}
+	_ provider.ProviderWithEphemeralResources = &RestapiProvider{}
+)
 
 // RestapiProvider defines the provider implementation.
 type RestapiProvider struct {
@@ -32,8 +40,13 @@ type RestapiProviderModel struct {
 	Username               types.String  `tfsdk:"username"`
 	Password               types.String  `tfsdk:"password"`
 	Headers                types.Map     `tfsdk:"headers"`
+	SensitiveHeaders       types.List    `tfsdk:"sensitive_headers"`
 	UseCookies             types.Bool    `tfsdk:"use_cookies"`
 	Timeout                types.Int64   `tfsdk:"timeout"`
+	CacheTTL               types.Int64   `tfsdk:"cache_ttl"`
+	RequestFormat          types.String  `tfsdk:"request_format"`
+	ResponseFormat         types.String  `tfsdk:"response_format"`
+	XMLRoot                types.String  `tfsdk:"xml_root"`
 	IDAttribute            types.String  `tfsdk:"id_attribute"`
 	CreateMethod           types.String  `tfsdk:"create_method"`
 	ReadMethod             types.String  `tfsdk:"read_method"`
@@ -45,6 +58,9 @@ type RestapiProviderModel struct {
 	WriteReturnsObject     types.Bool    `tfsdk:"write_returns_object"`
 	CreateReturnsObject    types.Bool    `tfsdk:"create_returns_object"`
 	XSSIPrefix             types.String  `tfsdk:"xssi_prefix"`
+	RequestEnvelope        types.String  `tfsdk:"request_envelope"`
+	ResponseKey            types.String  `tfsdk:"response_key"`
+	JSONAPI                types.Bool    `tfsdk:"jsonapi"`
 	RateLimit              types.Float64 `tfsdk:"rate_limit"`
 	TestPath               types.String  `tfsdk:"test_path"`
 	OAuthClientCredentials types.Object  `tfsdk:"oauth_client_credentials"`
@@ -52,6 +68,8 @@ type RestapiProviderModel struct {
 	KeyString              types.String  `tfsdk:"key_string"`
 	CertFile               types.String  `tfsdk:"cert_file"`
 	KeyFile                types.String  `tfsdk:"key_file"`
+	Tracing                types.Object  `tfsdk:"tracing"`
+	RecordHARFile          types.String  `tfsdk:"record_har_file"`
 }
 
 type OAuthClientCredentials struct {
@@ -62,6 +80,11 @@ type OAuthClientCredentials struct {
 	Scopes         types.List   `tfsdk:"scopes"`
 }
 
+type Tracing struct {
+	Endpoint types.String `tfsdk:"endpoint"`
+	Headers  types.Map    `tfsdk:"headers"`
+}
+
 type ResponseFilter struct {
 	Keys    types.List `tfsdk:"keys"`
 	Include types.Bool `tfsdk:"include"`
@@ -102,6 +125,13 @@ func (p *RestapiProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 					"are set and Authorization is one of the headers defined here, the basic authentication data will " +
 					"take precedence.",
 			},
+			"sensitive_headers": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Names of headers whose values are redacted from the logs, e.g. `X-API-Key`. " +
+					"The values of the `Authorization`, `Proxy-Authorization`, `Cookie` and `Set-Cookie` headers, " +
+					"the `password` and the OAuth client secret are always redacted.",
+			},
 			"use_cookies": schema.BoolAttribute{
 				Optional:    true,
 				Description: "Enable cookie jar to persist session.",
@@ -110,6 +140,36 @@ func (p *RestapiProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 				Optional:    true,
 				Description: "When set, will cause requests taking longer than this time (in seconds) to be aborted.",
 			},
+			"cache_ttl": schema.Int64Attribute{
+				Optional: true,
+				Description: "When set, responses of `GET` requests are cached in memory for this time (in seconds). " +
+					"Concurrent identical requests are only sent once and any write request to a path invalidates " +
+					"the cached responses of this path. This is useful to reduce the number of requests if many objects " +
+					"use `read_search` on the same collection.",
+			},
+			"request_format": schema.StringAttribute{
+				Optional: true,
+				Description: "Defaults to `json`. Format used to encode the object data in request bodies. " +
+					"One of `json`, `ndjson` (`application/x-ndjson`), `form` (`application/x-www-form-urlencoded`), " +
+					"`multipart` (`multipart/form-data`), `xml` or `yaml`. For `form` and `multipart`, nested objects " +
+					"are sent as `key[nested]` fields and arrays of values as repeated fields. For `xml`, see `response_format`.",
+			},
+			"response_format": schema.StringAttribute{
+				Optional: true,
+				Description: "Format used to decode response bodies. One of `json`, `ndjson`, `form`, `xml` or `yaml`. " +
+					"If not set, the format is detected from the `Content-Type` of the response, defaulting to `json` " +
+					"if there is none. Structured syntax suffixes like `application/vnd.api+json` or " +
+					"`application/problem+xml` are decoded with the respective format, and responses with an " +
+					"unsupported content type fail unless the format is set. `ndjson` responses are decoded to arrays. " +
+					"XML documents are mapped to objects without their root element: attributes are mapped to keys " +
+					"prefixed with `@`, e.g. `@id`, repeated elements to arrays and the text of elements with attributes " +
+					"or child elements to the key `#text`. All values are strings. The same mapping is used to encode " +
+					"`xml` request bodies.",
+			},
+			"xml_root": schema.StringAttribute{
+				Optional:    true,
+				Description: "Defaults to `root`. Name of the root element of `xml` request bodies.",
+			},
 			"id_attribute": schema.StringAttribute{
 				Optional: true,
 				Description: "If this option is set, it is used for editing REST objects. " +
@@ -162,7 +222,8 @@ func (p *RestapiProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 				Description: "Automatic detection of data drifts in the state. If activated, " +
 					"all keys in the `data` attribute are searched for in the `api_response`. If the attributes " +
 					"are found and the values differ from the current information in the `data` attribute, " +
-					"these are updated automatically. Defaults to `true`.",
+					"these are updated automatically. Numbers and booleans are compared by their string form with " +
+					"string values of the response, e.g. of XML documents. Defaults to `true`.",
 			},
 			"write_returns_object": schema.BoolAttribute{
 				Optional: true,
@@ -178,6 +239,25 @@ func (p *RestapiProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 				Optional:    true,
 				Description: "Trim the XSSI prefix from response string, if present, before parsing.",
 			},
+			"request_envelope": schema.StringAttribute{
+				Optional: true,
+				Description: "Key to wrap the request data in before it is sent to the API. " +
+					"For example, if the envelope is set to `data`, the request body is sent as `{ \"data\": { ... } }`. " +
+					"The format is `path/to/key` for nested envelopes.",
+			},
+			"response_key": schema.StringAttribute{
+				Optional: true,
+				Description: "Key to identify the object in the API response, e.g. `data` if the API responds with " +
+					"`{ \"data\": { ... }, \"meta\": { ... } }`. The format is `path/to/key`. If this key is omitted, " +
+					"it is assumed that the object is at the root of the response data.",
+			},
+			"jsonapi": schema.BoolAttribute{
+				Optional: true,
+				Description: "Enable JSON:API mode. Request data is sent as resource object with the keys `id`, `type` " +
+					"and `relationships` as members and all other keys as `attributes`. Resource objects of the API " +
+					"response are flattened the same way. `request_envelope`, `response_key` and `read_search.result_key` " +
+					"default to `data`.",
+			},
 			"rate_limit": schema.Float64Attribute{
 				Optional:    true,
 				Description: "Limits the number of requests per second sent to the API.",
@@ -243,6 +323,34 @@ func (p *RestapiProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 					"passphrase protected private keys. The most robust security protection available for the " +
 					"`key_file` is restrictive file system permissions.",
 			},
+			"tracing": schema.SingleNestedAttribute{
+				Optional: true,
+				Description: "Configuration for OpenTelemetry tracing. If set, a span is recorded for every object " +
+					"operation and API request, and the trace context is propagated to the API " +
+					"via the `traceparent` header.",
+				Attributes: map[string]schema.Attribute{
+					"endpoint": schema.StringAttribute{
+						Required: true,
+						Description: "URL of the OTLP/HTTP endpoint the traces are exported to, " +
+							"e.g. `http://localhost:4318`.",
+					},
+					"headers": schema.MapAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Sensitive:   true,
+						Description: "A map of headers sent with every export request to the OTLP endpoint.",
+					},
+				},
+			},
+			"record_har_file": schema.StringAttribute{
+				Optional: true,
+				Description: "Path of a HAR 1.2 file all requests and responses sent to the API are recorded to, " +
+					"including their timings and the time spent waiting for the `rate_limit`. " +
+					"The credentials, the values of `sensitive_headers` and the `sensitive_keys` of objects " +
+					"are redacted. Requests are appended if the file already exists, e.g. to record both " +
+					"plan and apply, and can be shared by provider aliases. Useful to debug API integrations, as HAR files " +
+					"can be opened in browser tools.",
+			},
 		},
 	}
 }
@@ -261,7 +369,7 @@ func (p *RestapiProvider) Configure(
 
 	respFilter := &ResponseFilter{}
 
-	clientOpts := &restclient.ClientOptions{}
+	clientOpts := &restclient.ClientOptions{Version: p.version}
 	clientOpts.ResponseFilter = &restclient.ResponseFilter{}
 
 	if !data.Endpoint.IsNull() && !data.Endpoint.IsUnknown() {
@@ -284,6 +392,10 @@ func (p *RestapiProvider) Configure(
 		resp.Diagnostics.Append(data.Headers.ElementsAs(ctx, &clientOpts.Headers, false)...)
 	}
 
+	if !data.SensitiveHeaders.IsNull() && !data.SensitiveHeaders.IsUnknown() {
+		resp.Diagnostics.Append(data.SensitiveHeaders.ElementsAs(ctx, &clientOpts.SensitiveHeaders, false)...)
+	}
+
 	if !data.UseCookies.IsNull() && !data.UseCookies.IsUnknown() {
 		clientOpts.UseCookies = data.UseCookies.ValueBool()
 	}
@@ -292,6 +404,22 @@ func (p *RestapiProvider) Configure(
 		clientOpts.Timeout = data.Timeout.ValueInt64()
 	}
 
+	if !data.CacheTTL.IsNull() && !data.CacheTTL.IsUnknown() {
+		clientOpts.CacheTTL = data.CacheTTL.ValueInt64()
+	}
+
+	if !data.RequestFormat.IsNull() && !data.RequestFormat.IsUnknown() {
+		clientOpts.RequestFormat = data.RequestFormat.ValueString()
+	}
+
+	if !data.ResponseFormat.IsNull() && !data.ResponseFormat.IsUnknown() {
+		clientOpts.ResponseFormat = data.ResponseFormat.ValueString()
+	}
+
+	if !data.XMLRoot.IsNull() && !data.XMLRoot.IsUnknown() {
+		clientOpts.XMLRoot = data.XMLRoot.ValueString()
+	}
+
 	if !data.IDAttribute.IsNull() && !data.IDAttribute.IsUnknown() {
 		clientOpts.IDAttribute = data.IDAttribute.ValueString()
 	}
@@ -346,6 +474,18 @@ func (p *RestapiProvider) Configure(
 		clientOpts.XSSIPrefix = data.XSSIPrefix.ValueString()
 	}
 
+	if !data.RequestEnvelope.IsNull() && !data.RequestEnvelope.IsUnknown() {
+		clientOpts.RequestEnvelope = data.RequestEnvelope.ValueString()
+	}
+
+	if !data.ResponseKey.IsNull() && !data.ResponseKey.IsUnknown() {
+		clientOpts.ResponseKey = data.ResponseKey.ValueString()
+	}
+
+	if !data.JSONAPI.IsNull() && !data.JSONAPI.IsUnknown() {
+		clientOpts.JSONAPI = data.JSONAPI.ValueBool()
+	}
+
 	if !data.RateLimit.IsNull() && !data.RateLimit.IsUnknown() {
 		clientOpts.RateLimit = data.RateLimit.ValueFloat64()
 	}
@@ -374,6 +514,23 @@ func (p *RestapiProvider) Configure(
 		clientOpts.KeyFile = data.KeyFile.ValueString()
 	}
 
+	if !data.Tracing.IsNull() && !data.Tracing.IsUnknown() {
+		tracing := &Tracing{}
+		clientOpts.Tracing = &restclient.TracingOptions{}
+
+		resp.Diagnostics.Append(data.Tracing.As(ctx, tracing, basetypes.ObjectAsOptions{})...)
+
+		clientOpts.Tracing.Endpoint = tracing.Endpoint.ValueString()
+
+		if !tracing.Headers.IsNull() && !tracing.Headers.IsUnknown() {
+			resp.Diagnostics.Append(tracing.Headers.ElementsAs(ctx, &clientOpts.Tracing.Headers, false)...)
+		}
+	}
+
+	if !data.RecordHARFile.IsNull() && !data.RecordHARFile.IsUnknown() {
+		clientOpts.RecordHARFile = data.RecordHARFile.ValueString()
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -390,17 +547,46 @@ func (p *RestapiProvider) Configure(
 
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.EphemeralResourceData = client
+	resp.ListResourceData = client
 }
 
 func (p *RestapiProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewRestobjectResource,
+		NewGraphqlMutationResource,
+		NewJSONRPCObjectResource,
+		NewRequestResource,
 	}
 }
 
 func (p *RestapiProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewRestobjectDataSource,
+		NewGraphqlQueryDataSource,
+		NewResponseDataSource,
+	}
+}
+
+func (p *RestapiProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewEphemeralRequestResource,
+	}
+}
+
+func (p *RestapiProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewRestobjectListResource,
+	}
+}
+
+func (p *RestapiProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewJSONGetFunction,
+		NewJSONMergeFunction,
+		NewJSONFilterFunction,
+		NewJSONPatchFunction,
+		NewJSONDiffFunction,
 	}
 }
 
@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+// testServer is a configured provider server to send protocol requests to.
+type testServer struct {
+	tfprotov6.ProviderServer
+
+	schemas    *tfprotov6.GetProviderSchemaResponse
+	identities *tfprotov6.GetResourceIdentitySchemasResponse
+}
+
+// newTestServer returns a provider server configured with the given endpoint.
+func newTestServer(t *testing.T, endpoint string) *testServer {
+	t.Helper()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("provider server creation failed: %v", err)
+	}
+
+	schemas, err := server.GetProviderSchema(t.Context(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("provider schema failed: %v", err)
+	}
+
+	identities, err := server.GetResourceIdentitySchemas(t.Context(), &tfprotov6.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		t.Fatalf("provider identity schema failed: %v", err)
+	}
+
+	s := &testServer{ProviderServer: server, schemas: schemas, identities: identities}
+
+	resp, err := server.ConfigureProvider(t.Context(), &tfprotov6.ConfigureProviderRequest{
+		Config: s.dynamicValue(t, schemas.Provider, map[string]tftypes.Value{
+			"endpoint":   tftypes.NewValue(tftypes.String, endpoint),
+			"rate_limit": tftypes.NewValue(tftypes.Number, 100),
+		}),
+	})
+	if err != nil {
+		t.Fatalf("provider configuration failed: %v", err)
+	}
+
+	assertNoDiagnostics(t, resp.Diagnostics)
+
+	return s
+}
+
+// resourceValue returns the value of the resource type with the given attributes
+// and all other attributes set to null.
+func (s *testServer) resourceValue(
+	t *testing.T, typeName string, values map[string]tftypes.Value,
+) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	return s.dynamicValue(t, s.schemas.ResourceSchemas[typeName], values)
+}
+
+// dataSourceValue returns the value of the data source type with the given
+// attributes and all other attributes set to null.
+func (s *testServer) dataSourceValue(
+	t *testing.T, typeName string, values map[string]tftypes.Value,
+) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	return s.dynamicValue(t, s.schemas.DataSourceSchemas[typeName], values)
+}
+
+// identityValue returns the identity of the resource type with the given
+// attributes and all other attributes set to null.
+func (s *testServer) identityValue(
+	t *testing.T, typeName string, values map[string]tftypes.Value,
+) *tfprotov6.ResourceIdentityData {
+	t.Helper()
+
+	typ, ok := s.identities.IdentitySchemas[typeName].ValueType().(tftypes.Object)
+	if !ok {
+		t.Fatal("unexpected identity schema type")
+	}
+
+	return &tfprotov6.ResourceIdentityData{IdentityData: newDynamicValue(t, typ, values)}
+}
+
+// resourceNull returns the null value of the resource type, e.g. as prior state
+// of a created resource.
+func (s *testServer) resourceNull(t *testing.T, typeName string) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	typ := s.schemas.ResourceSchemas[typeName].ValueType()
+
+	value, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, nil))
+	if err != nil {
+		t.Fatalf("dynamic value creation failed: %v", err)
+	}
+
+	return &value
+}
+
+func (s *testServer) dynamicValue(
+	t *testing.T, schema *tfprotov6.Schema, values map[string]tftypes.Value,
+) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	typ, ok := schema.ValueType().(tftypes.Object)
+	if !ok {
+		t.Fatal("unexpected schema type")
+	}
+
+	return newDynamicValue(t, typ, values)
+}
+
+func newDynamicValue(t *testing.T, typ tftypes.Object, values map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	attrs := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+
+	for name, attrType := range typ.AttributeTypes {
+		attrs[name] = tftypes.NewValue(attrType, nil)
+
+		if value, ok := values[name]; ok {
+			attrs[name] = value
+		}
+	}
+
+	value, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, attrs))
+	if err != nil {
+		t.Fatalf("dynamic value creation failed: %v", err)
+	}
+
+	return &value
+}
+
+// stateAttribute returns the string attribute of the given state, or an empty
+// string if it is null.
+func stateAttribute(t *testing.T, typ tftypes.Type, state *tfprotov6.DynamicValue, name string) string {
+	t.Helper()
+
+	value, err := state.Unmarshal(typ)
+	if err != nil {
+		t.Fatalf("state decoding failed: %v", err)
+	}
+
+	var attrs map[string]tftypes.Value
+
+	if err := value.As(&attrs); err != nil {
+		t.Fatalf("state decoding failed: %v", err)
+	}
+
+	var result *string
+
+	if err := attrs[name].As(&result); err != nil {
+		t.Fatalf("attribute %s decoding failed: %v", name, err)
+	}
+
+	if result == nil {
+		return ""
+	}
+
+	return *result
+}
+
+func assertNoDiagnostics(t *testing.T, diags []*tfprotov6.Diagnostic) {
+	t.Helper()
+
+	for _, diag := range diags {
+		assert.NotEqual(t, tfprotov6.DiagnosticSeverityError, diag.Severity, "%s: %s", diag.Summary, diag.Detail)
+	}
+}
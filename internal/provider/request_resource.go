@@ -0,0 +1,362 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restrequest"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource               = &RequestResource{}
+	_ resource.ResourceWithModifyPlan = &RequestResource{}
+)
+
+func NewRequestResource() resource.Resource {
+	return &RequestResource{}
+}
+
+// RequestResource defines the resource implementation.
+type RequestResource struct {
+	client *restclient.RestClient
+}
+
+type RequestResourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	Method              types.String `tfsdk:"method"`
+	Path                types.String `tfsdk:"path"`
+	QueryParams         types.Map    `tfsdk:"query_params"`
+	Body                types.String `tfsdk:"body"`
+	ContentType         types.String `tfsdk:"content_type"`
+	Headers             types.Map    `tfsdk:"headers"`
+	ExpectedStatusCodes types.List   `tfsdk:"expected_status_codes"`
+	Triggers            types.Map    `tfsdk:"triggers"`
+	DestroyMethod       types.String `tfsdk:"destroy_method"`
+	DestroyPath         types.String `tfsdk:"destroy_path"`
+	DestroyBody         types.String `tfsdk:"destroy_body"`
+	StatusCode          types.Int64  `tfsdk:"status_code"`
+	ResponseHeaders     types.Map    `tfsdk:"response_headers"`
+	ResponseBody        types.String `tfsdk:"response_body"`
+}
+
+func (r *RequestResource) Metadata(
+	_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_request"
+}
+
+func (r *RequestResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	// Consider data sensitive if env variables is set to true.
+	isDataSensitive, _ := strconv.ParseBool(utils.GetEnvOrDefault("RESTAPI_SENSITIVE_DATA", "false"))
+
+	resp.Schema = schema.Schema{
+		Description: "Restapi request resource schema. Sends a request once on creation, e.g. to trigger " +
+			"an action on the API server, and again whenever `triggers` change. Changes of other attributes " +
+			"do not send the request again.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Internal resource ID.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"method": schema.StringAttribute{
+				Description: "Defaults to `POST`. The HTTP method of the request.",
+				Optional:    true,
+			},
+			"path": schema.StringAttribute{
+				Description: "The API path of the request in addition to the base URL defined in the provider configuration.",
+				Required:    true,
+			},
+			"query_params": schema.MapAttribute{
+				ElementType: types.StringType,
+				Description: "Query parameters added to the query string of the request.",
+				Optional:    true,
+			},
+			"body": schema.StringAttribute{
+				Description: "The body of the request, e.g. a JSON document created with `jsonencode`.",
+				Optional:    true,
+				Sensitive:   isDataSensitive,
+			},
+			"content_type": schema.StringAttribute{
+				Description: "Defaults to `application/json` if `body` is set. The content type of the request body.",
+				Optional:    true,
+			},
+			"headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Description: "Headers of the requests, which take precedence over the `headers` defined " +
+					"in the provider configuration.",
+				Optional: true,
+			},
+			"expected_status_codes": schema.ListAttribute{
+				ElementType: types.Int64Type,
+				Description: "Status codes of successful responses. Defaults to all `2xx` status codes. " +
+					"Responses with other status codes fail the request.",
+				Optional: true,
+			},
+			"triggers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Description: "Arbitrary values that cause the request to be sent again when changed.",
+				Optional:    true,
+			},
+			"destroy_method": schema.StringAttribute{
+				Description: "Defaults to `DELETE`. The HTTP method of the request on destroy.",
+				Optional:    true,
+			},
+			"destroy_path": schema.StringAttribute{
+				Description: "The API path of the request on destroy. If not set, no request is sent on destroy " +
+					"and the resource is only removed from the state.",
+				Optional: true,
+			},
+			"destroy_body": schema.StringAttribute{
+				Description: "The body of the request on destroy.",
+				Optional:    true,
+				Sensitive:   isDataSensitive,
+			},
+			"status_code": schema.Int64Attribute{
+				Description: "The status code of the last response.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"response_headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Description: "The headers of the last response. Multiple values of a header are joined by `, `. " +
+					"The values of sensitive headers, e.g. `Set-Cookie` or the `sensitive_headers` of the provider, " +
+					"are redacted.",
+				Computed: true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"response_body": schema.StringAttribute{
+				Description: "The raw body of the last response.",
+				Computed:    true,
+				Sensitive:   isDataSensitive,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RequestResource) Configure(
+	_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*restclient.RestClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *restapi.Client, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan marks the response attributes as unknown if the request is sent
+// again because the triggers changed.
+func (r *RequestResource) ModifyPlan(
+	ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse,
+) {
+	// Nothing to do on create and destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state RequestResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() || plan.Triggers.Equal(state.Triggers) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("status_code"), types.Int64Unknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("response_headers"),
+		types.MapUnknown(types.StringType))...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("response_body"), types.StringUnknown())...)
+}
+
+func (r *RequestResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RequestResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(rand.Text())
+
+	resp.Diagnostics.Append(r.send(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *RequestResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RequestResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The request is only sent on changes, so there is nothing to refresh.
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *RequestResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state RequestResourceModel
+
+	// Read Terraform plan and prior state data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Triggers.Equal(state.Triggers) {
+		resp.Diagnostics.Append(r.send(ctx, &data)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *RequestResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RequestResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() || data.DestroyPath.IsNull() {
+		return
+	}
+
+	opts, diags := toRequestOptions(ctx, data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts.Method = http.MethodDelete
+	if !data.DestroyMethod.IsNull() {
+		opts.Method = data.DestroyMethod.ValueString()
+	}
+
+	opts.Path = data.DestroyPath.ValueString()
+	opts.Body = data.DestroyBody.ValueString()
+
+	if _, err := restrequest.Send(ctx, r.client, opts); err != nil {
+		resp.Diagnostics.AddError("Client Error", err.Error())
+	}
+}
+
+// send sends the request and maps the response to the model.
+func (r *RequestResource) send(ctx context.Context, data *RequestResourceModel) diag.Diagnostics {
+	opts, diags := toRequestOptions(ctx, *data)
+	if diags.HasError() {
+		return diags
+	}
+
+	resp, err := restrequest.Send(ctx, r.client, opts)
+	if err != nil {
+		diags.AddError("Client Error", err.Error())
+
+		return diags
+	}
+
+	data.StatusCode = types.Int64Value(int64(resp.StatusCode))
+	data.ResponseBody = types.StringValue(resp.Body)
+	data.ResponseHeaders, diags = mapResponseHeaders(ctx, r.client.RedactHeaders(resp.Header))
+
+	return diags
+}
+
+func toRequestOptions(ctx context.Context, data RequestResourceModel) (*restrequest.RequestOptions, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	opts := &restrequest.RequestOptions{
+		Method:      http.MethodPost,
+		Path:        data.Path.ValueString(),
+		Body:        data.Body.ValueString(),
+		ContentType: data.ContentType.ValueString(),
+	}
+
+	if !data.Method.IsNull() && !data.Method.IsUnknown() {
+		opts.Method = data.Method.ValueString()
+	}
+
+	if !data.QueryParams.IsNull() && !data.QueryParams.IsUnknown() {
+		diags.Append(data.QueryParams.ElementsAs(ctx, &opts.QueryParams, false)...)
+	}
+
+	if !data.Headers.IsNull() && !data.Headers.IsUnknown() {
+		diags.Append(data.Headers.ElementsAs(ctx, &opts.Headers, false)...)
+	}
+
+	if !data.ExpectedStatusCodes.IsNull() && !data.ExpectedStatusCodes.IsUnknown() {
+		diags.Append(data.ExpectedStatusCodes.ElementsAs(ctx, &opts.ExpectedStatusCodes, false)...)
+	}
+
+	return opts, diags
+}
+
+// mapResponseHeaders converts the response headers to a map value. Multiple
+// values of a header are joined by a comma.
+func mapResponseHeaders(ctx context.Context, header http.Header) (types.Map, diag.Diagnostics) {
+	headers := make(map[string]string, len(header))
+	for name, values := range header {
+		headers[name] = strings.Join(values, ", ")
+	}
+
+	return types.MapValueFrom(ctx, types.StringType, headers)
+}
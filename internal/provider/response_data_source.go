@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restrequest"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ResponseDataSource{}
+
+func NewResponseDataSource() datasource.DataSource {
+	return &ResponseDataSource{}
+}
+
+// ResponseDataSource defines the data source implementation.
+type ResponseDataSource struct {
+	client *restclient.RestClient
+}
+
+type ResponseDataSourceModel struct {
+	Method              types.String `tfsdk:"method"`
+	Path                types.String `tfsdk:"path"`
+	QueryParams         types.Map    `tfsdk:"query_params"`
+	Headers             types.Map    `tfsdk:"headers"`
+	ExpectedStatusCodes types.List   `tfsdk:"expected_status_codes"`
+	ResponseFormat      types.String `tfsdk:"response_format"`
+	ResponseKey         types.String `tfsdk:"response_key"`
+	StatusCode          types.Int64  `tfsdk:"status_code"`
+	ResponseHeaders     types.Map    `tfsdk:"response_headers"`
+	ResponseBody        types.String `tfsdk:"response_body"`
+	ResponseData        types.String `tfsdk:"response_data"`
+}
+
+func (d *ResponseDataSource) Metadata(
+	_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_response"
+}
+
+func (d *ResponseDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	// Consider data sensitive if env variables is set to true.
+	isDataSensitive, _ := strconv.ParseBool(utils.GetEnvOrDefault("RESTAPI_SENSITIVE_DATA", "false"))
+
+	resp.Schema = schema.Schema{
+		Description: "Restapi response data source schema. Sends a single request and exposes the response, " +
+			"e.g. to read values from a discovery document or version endpoint.",
+
+		Attributes: map[string]schema.Attribute{
+			"method": schema.StringAttribute{
+				Description: "Defaults to `GET`. The HTTP method of the request.",
+				Optional:    true,
+			},
+			"path": schema.StringAttribute{
+				Description: "The API path of the request in addition to the base URL defined in the provider configuration.",
+				Required:    true,
+			},
+			"query_params": schema.MapAttribute{
+				ElementType: types.StringType,
+				Description: "Query parameters added to the query string of the request.",
+				Optional:    true,
+			},
+			"headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Description: "Headers of the request, which take precedence over the `headers` defined " +
+					"in the provider configuration.",
+				Optional: true,
+			},
+			"expected_status_codes": schema.ListAttribute{
+				ElementType: types.Int64Type,
+				Description: "Status codes of successful responses. Defaults to all `2xx` status codes. " +
+					"Responses with other status codes fail the request.",
+				Optional: true,
+			},
+			"response_format": schema.StringAttribute{
+				Description: "Defaults to `response_format` defined in the provider configuration. " +
+					"Allows override of `response_format` (see `response_format` provider documentation) per data source.",
+				Optional: true,
+			},
+			"response_key": schema.StringAttribute{
+				Description: "Slash-delimited key path of the value in the decoded response body that is exposed " +
+					"as `response_data`, e.g. `zones/0`. Defaults to the whole body.",
+				Optional: true,
+			},
+			"status_code": schema.Int64Attribute{
+				Description: "The status code of the response.",
+				Computed:    true,
+			},
+			"response_headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Description: "The headers of the response. Multiple values of a header are joined by `, `. " +
+					"The values of sensitive headers, e.g. `Set-Cookie` or the `sensitive_headers` of the provider, " +
+					"are redacted.",
+				Computed: true,
+			},
+			"response_body": schema.StringAttribute{
+				Description: "The raw body of the response.",
+				Computed:    true,
+				Sensitive:   isDataSensitive,
+			},
+			"response_data": schema.StringAttribute{
+				Description: "JSON encoded value of the decoded response body at `response_key`, " +
+					"usable with `jsondecode`. Null if the response body can not be decoded and no " +
+					"`response_key` is set.",
+				Computed:  true,
+				Sensitive: isDataSensitive,
+			},
+		},
+	}
+}
+
+func (d *ResponseDataSource) Configure(
+	_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*restclient.RestClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected *http.Client, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ResponseDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ResponseDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := &restrequest.RequestOptions{
+		Method: http.MethodGet,
+		Path:   data.Path.ValueString(),
+	}
+
+	if !data.Method.IsNull() && !data.Method.IsUnknown() {
+		opts.Method = data.Method.ValueString()
+	}
+
+	if !data.QueryParams.IsNull() && !data.QueryParams.IsUnknown() {
+		resp.Diagnostics.Append(data.QueryParams.ElementsAs(ctx, &opts.QueryParams, false)...)
+	}
+
+	if !data.Headers.IsNull() && !data.Headers.IsUnknown() {
+		resp.Diagnostics.Append(data.Headers.ElementsAs(ctx, &opts.Headers, false)...)
+	}
+
+	if !data.ExpectedStatusCodes.IsNull() && !data.ExpectedStatusCodes.IsUnknown() {
+		resp.Diagnostics.Append(data.ExpectedStatusCodes.ElementsAs(ctx, &opts.ExpectedStatusCodes, false)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiResp, err := restrequest.Send(ctx, d.client, opts)
+	if err != nil {
+		resp.Diagnostics.AddError("Can not read response", err.Error())
+
+		return
+	}
+
+	format := data.ResponseFormat.ValueString()
+	if format == "" {
+		format = d.client.Options.ResponseFormat
+	}
+
+	data.StatusCode = types.Int64Value(int64(apiResp.StatusCode))
+	data.ResponseBody = types.StringValue(apiResp.Body)
+	data.ResponseData = types.StringNull()
+
+	// Responses that can not be decoded, e.g. plain text, are only exposed as raw body,
+	// unless a value is explicitly requested by response key.
+	responseData, err := restrequest.DecodeResponse(d.client, apiResp, format, data.ResponseKey.ValueString())
+	if err == nil {
+		data.ResponseData = types.StringValue(responseData)
+	} else if !data.ResponseKey.IsNull() {
+		resp.Diagnostics.AddError("Can not decode response", err.Error())
+
+		return
+	}
+
+	headers, diags := mapResponseHeaders(ctx, d.client.RedactHeaders(apiResp.Header))
+	resp.Diagnostics.Append(diags...)
+	data.ResponseHeaders = headers
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
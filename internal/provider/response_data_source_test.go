@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseDataSourceRead(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		key         string
+		want        string
+		wantErr     string
+	}{
+		{
+			name:        "json",
+			contentType: "application/json",
+			body:        `{"version": "1.2.3"}`,
+			want:        `{"version": "1.2.3"}`,
+		},
+		{
+			name:        "json array key",
+			contentType: "application/json",
+			body:        `[{"name": "a"}]`,
+			key:         "0/name",
+			want:        `"a"`,
+		},
+		{
+			name:        "plain text",
+			contentType: "text/plain",
+			body:        "1.2.3",
+		},
+		{
+			name:        "html",
+			contentType: "text/html",
+			body:        "<html></html>",
+		},
+		{
+			name:        "plain text key",
+			contentType: "text/plain",
+			body:        "1.2.3",
+			key:         "version",
+			wantErr:     "Can not decode response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			t.Cleanup(api.Close)
+
+			server := newTestServer(t, api.URL)
+			typ := server.schemas.DataSourceSchemas["restapi_response"].ValueType()
+
+			attrs := map[string]tftypes.Value{
+				"path": tftypes.NewValue(tftypes.String, "/version"),
+			}
+
+			if tt.key != "" {
+				attrs["response_key"] = tftypes.NewValue(tftypes.String, tt.key)
+			}
+
+			resp, err := server.ReadDataSource(t.Context(), &tfprotov6.ReadDataSourceRequest{
+				TypeName: "restapi_response",
+				Config:   server.dataSourceValue(t, "restapi_response", attrs),
+			})
+			assert.NoError(t, err)
+
+			if tt.wantErr != "" {
+				assert.Len(t, resp.Diagnostics, 1)
+				assert.Equal(t, tt.wantErr, resp.Diagnostics[0].Summary)
+
+				return
+			}
+
+			assertNoDiagnostics(t, resp.Diagnostics)
+			assert.Equal(t, tt.body, stateAttribute(t, typ, resp.State, "response_body"))
+			assert.Equal(t, tt.want, stateAttribute(t, typ, resp.State, "response_data"))
+		})
+	}
+}
+
+func TestResponseDataSourceRedactHeaders(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.Header().Set("X-Request-Id", "1")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(api.Close)
+
+	server := newTestServer(t, api.URL)
+	typ := server.schemas.DataSourceSchemas["restapi_response"].ValueType()
+
+	resp, err := server.ReadDataSource(t.Context(), &tfprotov6.ReadDataSourceRequest{
+		TypeName: "restapi_response",
+		Config: server.dataSourceValue(t, "restapi_response", map[string]tftypes.Value{
+			"path": tftypes.NewValue(tftypes.String, "/version"),
+		}),
+	})
+	assert.NoError(t, err)
+	assertNoDiagnostics(t, resp.Diagnostics)
+
+	state, err := resp.State.Unmarshal(typ)
+	assert.NoError(t, err)
+
+	var (
+		attrs   map[string]tftypes.Value
+		headers map[string]tftypes.Value
+	)
+
+	assert.NoError(t, state.As(&attrs))
+	assert.NoError(t, attrs["response_headers"].As(&headers))
+	assert.Equal(t, tftypes.NewValue(tftypes.String, utils.RedactedValue), headers["Set-Cookie"])
+	assert.Equal(t, tftypes.NewValue(tftypes.String, "1"), headers["X-Request-Id"])
+}
@@ -11,11 +11,15 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
-var _ datasource.DataSource = &RestobjectDataSource{}
+var (
+	_ datasource.DataSource                   = &RestobjectDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &RestobjectDataSource{}
+)
 
 func NewRestobjectDataSource() datasource.DataSource {
 	return &RestobjectDataSource{}
@@ -46,31 +50,35 @@ func (d *RestobjectDataSource) Schema(_ context.Context, _ datasource.SchemaRequ
 			},
 			"path": schema.StringAttribute{
 				Description: "The API path in addition to the base URL defined in the provider configuration, " +
-					"which represents objects of this type on the API server.",
+					"which represents objects of this type on the API server. For searches with `read_search`, " +
+					"the placeholders `{search_key}` and `{search_value}` are replaced by the URL-escaped search criteria. " +
+					"All paths support the placeholders `{data.<key>}`, which is replaced by the URL-escaped value at " +
+					"the slash-delimited key path in `data`, and `{env.<name>}`, which is replaced by the URL-escaped " +
+					"value of the environment variable.",
 				Required: true,
 			},
 			"create_path": schema.StringAttribute{
 				Description: "Defaults to `path`. The API path that specifies where objects of this type " +
-					"are to be created (`POST`) on the API server. The string `{id}` is replaced by the Terraform ID " +
-					"of the object if the data contains the attribute `id_attribute`.",
+					"are to be created (`POST`) on the API server. The string `{id}` is replaced by the URL-escaped " +
+					"Terraform ID of the object if the data contains the attribute `id_attribute`.",
 				Computed: true,
 			},
 			"read_path": schema.StringAttribute{
 				Description: "Defaults to `path/{id}`. The API path that specifies where objects of this type " +
-					"can be read (`GET`) on the API server. The string `{id}` is replaced by the Terraform ID of " +
-					"the object.",
+					"can be read (`GET`) on the API server. The string `{id}` is replaced by the URL-escaped " +
+					"Terraform ID of the object.",
 				Optional: true,
 			},
 			"update_path": schema.StringAttribute{
 				Description: "Defaults to `path/{id}`. The API path that specifies where objects of this type " +
-					"can be updated (`PUT`) on the API server. The string `{id}` is replaced by the Terraform ID of " +
-					"the object.",
+					"can be updated (`PUT`) on the API server. The string `{id}` is replaced by the URL-escaped " +
+					"Terraform ID of the object.",
 				Computed: true,
 			},
 			"destroy_path": schema.StringAttribute{
 				Description: "Defaults to `path/{id}`. The API path that specifies where objects of this type " +
-					"can be deleted (`DELETE`) on the API server. The string `{id}` is replaced by the Terraform ID of " +
-					"the object.",
+					"can be deleted (`DELETE`) on the API server. The string `{id}` is replaced by the URL-escaped " +
+					"Terraform ID of the object.",
 				Computed: true,
 			},
 			"create_method": schema.StringAttribute{
@@ -98,19 +106,59 @@ func (d *RestobjectDataSource) Schema(_ context.Context, _ datasource.SchemaRequ
 					"Allows override of `id_attribute` (see `id_attribute` provider documentation) per data source.",
 				Optional: true,
 			},
+			"id_attributes": schema.ListAttribute{
+				ElementType: types.StringType,
+				Description: "List of attributes to build a composite ID for objects that are only uniquely " +
+					"addressable by multiple values. Takes precedence over `id_attribute`. The values are joined by " +
+					"`id_separator` to build the Terraform ID, and each component is available as placeholder " +
+					"`{id.<attribute>}` in `read_path`, e.g. `/orgs/{id.org}/teams/{id.slug}`.",
+				Optional: true,
+			},
+			"id_separator": schema.StringAttribute{
+				Description: "Defaults to `:`. Separator used to join the components of a composite ID (see `id_attributes`).",
+				Optional:    true,
+			},
+			"raw_id": schema.BoolAttribute{
+				Description: "Defaults to `false`. The object ID and its components are URL-escaped when replacing " +
+					"the `{id}` and `{id.<attribute>}` placeholders in the `*_path` attributes. Set to `true` to insert " +
+					"them unescaped, e.g. for APIs that expect IDs containing slashes as multiple path segments.",
+				Optional: true,
+			},
 			"object_id": schema.StringAttribute{
-				Description: "Defaults to the auto-generated `id` gathered during normal operations and `id_attribute`. " +
-					"Allows to set the ID manually. This is used in conjunction with the `*_path` attributes.",
+				Description: "ID of the object to read from `read_path`. If not set, the object is searched with " +
+					"`read_search` and the ID is gathered from `id_attribute`. Either `object_id` or `read_search` " +
+					"must be set.",
+				Optional: true,
 				Computed: true,
 			},
+			"response_key": schema.StringAttribute{
+				Description: "Defaults to `response_key` defined in the provider configuration. " +
+					"Allows override of `response_key` (see `response_key` provider documentation) per data source.",
+				Optional: true,
+			},
+			"jsonapi": schema.BoolAttribute{
+				Description: "Defaults to `jsonapi` defined in the provider configuration. " +
+					"Allows to enable or disable JSON:API mode (see `jsonapi` provider documentation) per data source.",
+				Optional: true,
+			},
+			"response_format": schema.StringAttribute{
+				Description: "Defaults to `response_format` defined in the provider configuration. " +
+					"Allows override of `response_format` (see `response_format` provider documentation) per data source.",
+				Optional: true,
+			},
+			"xml_root": schema.StringAttribute{
+				Description: "Defaults to `xml_root` defined in the provider configuration. " +
+					"Allows override of `xml_root` (see `xml_root` provider documentation) per data source.",
+				Optional: true,
+			},
 			"data": schema.StringAttribute{
 				Description: "JSON object managed by the provider that holds information from the API response.",
 				Computed:    true,
 				Sensitive:   isDataSensitive,
 			},
 			"read_search": schema.SingleNestedAttribute{
-				Description: "Custom search for `read_path`.",
-				Required:    true,
+				Description: "Custom search for `read_path`. Either `object_id` or `read_search` must be set.",
+				Optional:    true,
 				Attributes: map[string]schema.Attribute{
 					"search_key": schema.StringAttribute{
 						Description: "Key to identify a specific data record in the data array. " +
@@ -131,8 +179,10 @@ func (d *RestobjectDataSource) Schema(_ context.Context, _ datasource.SchemaRequ
 						Optional: true,
 					},
 					"query_string": schema.StringAttribute{
-						Description: "Defaults to `query_string`. Optional query string used for API read requests.",
-						Optional:    true,
+						Description: "Defaults to `query_string`. Optional query string used for API read requests. " +
+							"The placeholders `{search_key}` and `{search_value}` are replaced by the URL-escaped " +
+							"search criteria, which allows the API to filter the results server-side.",
+						Optional: true,
 					},
 				},
 			},
@@ -158,6 +208,21 @@ func (d *RestobjectDataSource) Schema(_ context.Context, _ datasource.SchemaRequ
 				Computed:    true,
 				Sensitive:   isDataSensitive,
 			},
+			"sensitive_keys": schema.ListAttribute{
+				ElementType: types.StringType,
+				Description: "List of slash-delimited key paths of sensitive values in the API response, e.g. " +
+					"`token` or `keys/*/secret`, where `*` matches all keys or array elements. The values are masked " +
+					"in `api_response` and `api_response_raw`, exposed by `sensitive_values` and redacted from the " +
+					"request and response bodies in the logs. Non-JSON bodies are redacted from the logs completely.",
+				Optional: true,
+			},
+			"sensitive_values": schema.MapAttribute{
+				ElementType: types.StringType,
+				Description: "The values of `sensitive_keys` in the API response, mapped by their key path. " +
+					"Like `api_response`, the value is the `golang fmt` representation of the value.",
+				Computed:  true,
+				Sensitive: true,
+			},
 			"update_data": schema.StringAttribute{
 				Computed:    true,
 				Description: "JSON object that is passed to update requests.",
@@ -197,6 +262,31 @@ func (d *RestobjectDataSource) Configure(
 	d.client = client
 }
 
+func (d *RestobjectDataSource) ValidateConfig(
+	ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse,
+) {
+	var data RestobjectResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Unknown values might be set later.
+	if data.ObjectID.IsUnknown() || data.ReadSearch.IsUnknown() {
+		return
+	}
+
+	if data.ObjectID.IsNull() == data.ReadSearch.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("object_id"),
+			"Invalid Attribute Combination",
+			"Exactly one of `object_id` or `read_search` must be set.",
+		)
+	}
+}
+
 func (d *RestobjectDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data RestobjectResourceModel
 
@@ -221,17 +311,27 @@ func (d *RestobjectDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	if _, err = ro.Find(
-		ctx, data.QueryString.ValueString(),
-		ro.Options.ReadSearch.SearchKey,
-		ro.Options.ReadSearch.SearchValue,
-		ro.Options.ReadSearch.ResultKey,
-	); err != nil {
-		resp.Diagnostics.AddError("Can not find restobject", err.Error())
+	// Search the object only if the ID is not known already.
+	if data.ObjectID.IsNull() {
+		queryString := ro.Options.ReadSearch.QueryString
+		if queryString == "" {
+			queryString = ro.Options.QueryString
+		}
 
-		return
+		if _, err = ro.Find(
+			ctx, queryString,
+			ro.Options.ReadSearch.SearchKey,
+			ro.Options.ReadSearch.SearchValue,
+			ro.Options.ReadSearch.ResultKey,
+		); err != nil {
+			resp.Diagnostics.AddError("Can not find restobject", err.Error())
+
+			return
+		}
 	}
 
+	objectID := ro.Options.ID
+
 	err = ro.Read(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Can not read restobject", err.Error())
@@ -239,7 +339,16 @@ func (d *RestobjectDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
+	// The object ID is removed if the object does not exist (anymore).
+	if ro.Options.ID == "" {
+		resp.Diagnostics.AddError("Can not read restobject",
+			fmt.Sprintf("object '%s' not found at '%s'", objectID, ro.Options.GetPath))
+
+		return
+	}
+
 	resp.Diagnostics.Append(mapFields(ctx, ro.Options, &data)...)
+	data.ObjectID = types.StringValue(ro.Options.ID)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
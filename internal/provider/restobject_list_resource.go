@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restobject"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ list.ListResource              = &RestobjectListResource{}
+	_ list.ListResourceWithConfigure = &RestobjectListResource{}
+)
+
+func NewRestobjectListResource() list.ListResource {
+	return &RestobjectListResource{}
+}
+
+// RestobjectListResource defines the list resource implementation.
+type RestobjectListResource struct {
+	client *restclient.RestClient
+}
+
+type RestobjectListResourceModel struct {
+	Path           types.String `tfsdk:"path"`
+	QueryString    types.String `tfsdk:"query_string"`
+	ResultKey      types.String `tfsdk:"result_key"`
+	NextKey        types.String `tfsdk:"next_key"`
+	IDAttribute    types.String `tfsdk:"id_attribute"`
+	ResponseFormat types.String `tfsdk:"response_format"`
+	DataKeys       types.List   `tfsdk:"data_keys"`
+}
+
+func (r *RestobjectListResource) Metadata(
+	_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_object"
+}
+
+func (r *RestobjectListResource) ListResourceConfigSchema(
+	_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		Description: "Restapi object list resource schema. Lists the objects of a collection, " +
+			"e.g. to generate the configuration of existing objects with `terraform query`.",
+
+		Attributes: map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				Description: "The API path of the collection in addition to the base URL defined in the " +
+					"provider configuration. Supports the same placeholders as `path` of `restapi_object`.",
+				Required: true,
+			},
+			"query_string": schema.StringAttribute{
+				Description: "An optional query string to send when listing the objects.",
+				Optional:    true,
+			},
+			"result_key": schema.StringAttribute{
+				Description: "Slash-delimited key path of the array of objects in the response, " +
+					"e.g. `data/items`. The response is expected to be an array if not set.",
+				Optional: true,
+			},
+			"next_key": schema.StringAttribute{
+				Description: "Slash-delimited key path of the path or URL of the next page in the response, " +
+					"e.g. `links/next`. Relative links are resolved against the provider `endpoint`, links to other hosts " +
+					"are rejected. The pages are listed until the key is missing or empty. " +
+					"Only the first page is listed if not set.",
+				Optional: true,
+			},
+			"id_attribute": schema.StringAttribute{
+				Description: "Defaults to `id_attribute` set on the provider. " +
+					"Allows per-resource override of `id_attribute` (see `id_attribute` provider config documentation).",
+				Optional: true,
+			},
+			"response_format": schema.StringAttribute{
+				Description: "Defaults to `response_format` defined in the provider configuration. " +
+					"Allows override of `response_format` (see `response_format` provider documentation).",
+				Optional: true,
+			},
+			"data_keys": schema.ListAttribute{
+				ElementType: types.StringType,
+				Description: "Top-level keys of the listed objects used as `data` of the generated resources. " +
+					"Defaults to all keys of the objects filtered by the `response_filter` of the provider.",
+				Optional: true,
+			},
+		},
+	}
+}
+
+func (r *RestobjectListResource) Configure(
+	_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*restclient.RestClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf(
+				"Expected *http.Client, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RestobjectListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var (
+		data     RestobjectListResourceModel
+		dataKeys []string
+	)
+
+	diags := req.Config.Get(ctx, &data)
+
+	if !data.DataKeys.IsNull() && !data.DataKeys.IsUnknown() {
+		diags.Append(data.DataKeys.ElementsAs(ctx, &dataKeys, false)...)
+	}
+
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	objectOpts := &restobject.ObjectOptions{
+		Path:           data.Path.ValueString(),
+		IDAttribute:    data.IDAttribute.ValueString(),
+		ResponseFormat: data.ResponseFormat.ValueString(),
+	}
+
+	listOpts := &restobject.ListOptions{
+		QueryString: data.QueryString.ValueString(),
+		ResultKey:   data.ResultKey.ValueString(),
+		NextKey:     data.NextKey.ValueString(),
+		Limit:       int(req.Limit),
+	}
+
+	ro, err := restobject.New(r.client, objectOpts)
+	if err != nil {
+		diags.AddError("Failed to create API client", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	objects, err := ro.List(ctx, listOpts)
+	if err != nil {
+		diags.AddError("Client Error", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, obj := range objects {
+			result := req.NewListResult(ctx)
+			result.DisplayName = obj.Options.ID
+			result.Diagnostics.Append(mapListResult(ctx, data, obj, dataKeys, req.IncludeResource, &result)...)
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// mapListResult sets the identity of the listed object and, if requested, the
+// resource with the data populated from the listed object like an import.
+func mapListResult(
+	ctx context.Context,
+	data RestobjectListResourceModel,
+	obj *restobject.RestObject,
+	dataKeys []string,
+	includeResource bool,
+	result *list.ListResult,
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	model := newImportModel()
+	model.ID = types.StringValue(obj.Options.ID)
+	model.Path = data.Path
+	model.IDAttribute = data.IDAttribute
+	model.ResponseFormat = data.ResponseFormat
+
+	diags.Append(mapIdentity(ctx, model, result.Identity)...)
+
+	if !includeResource {
+		return diags
+	}
+
+	if err := setImportData(obj.Options, dataKeys); err != nil {
+		diags.AddError("Can not map fields", err.Error())
+
+		return diags
+	}
+
+	diags.Append(mapFields(ctx, obj.Options, &model)...)
+	diags.Append(result.Resource.Set(ctx, newWriteOnlyModel(model))...)
+
+	return diags
+}
@@ -12,18 +12,25 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
+// secretDataKeysKey is the private state key of the secret_data keys.
+const secretDataKeysKey = "secret_data_keys"
+
 // Ensure provider defined types fully satisfy framework interfaces.
 var (
 	_ resource.Resource                = &RestobjectResource{}
 	_ resource.ResourceWithImportState = &RestobjectResource{}
+	_ resource.ResourceWithIdentity    = &RestobjectResource{}
 )
 
 func NewRestobjectResource() resource.Resource {
@@ -50,9 +57,17 @@ type RestobjectResourceModel struct {
 	QueryString types.String `tfsdk:"query_string"`
 	ReadSearch  types.Object `tfsdk:"read_search"`
 
-	ID          types.String `tfsdk:"id"`
-	IDAttribute types.String `tfsdk:"id_attribute"`
-	ObjectID    types.String `tfsdk:"object_id"`
+	ID           types.String `tfsdk:"id"`
+	IDAttribute  types.String `tfsdk:"id_attribute"`
+	IDAttributes types.List   `tfsdk:"id_attributes"`
+	IDSeparator  types.String `tfsdk:"id_separator"`
+	RawID        types.Bool   `tfsdk:"raw_id"`
+	ObjectID     types.String `tfsdk:"object_id"`
+
+	ResponseKey    types.String `tfsdk:"response_key"`
+	JSONAPI        types.Bool   `tfsdk:"jsonapi"`
+	ResponseFormat types.String `tfsdk:"response_format"`
+	XMLRoot        types.String `tfsdk:"xml_root"`
 
 	Data              types.String `tfsdk:"data"`
 	UpdateData        types.String `tfsdk:"update_data"`
@@ -60,6 +75,31 @@ type RestobjectResourceModel struct {
 	APIResponse       types.Map    `tfsdk:"api_response"`
 	APIResponseRaw    types.String `tfsdk:"api_response_raw"`
 	CreateResponseRaw types.String `tfsdk:"create_response_raw"`
+
+	SensitiveKeys   types.List `tfsdk:"sensitive_keys"`
+	SensitiveValues types.Map  `tfsdk:"sensitive_values"`
+}
+
+// RestobjectWriteOnlyModel extends the resource model by the request body and
+// write-only attributes, which are not supported by the data source.
+type RestobjectWriteOnlyModel struct {
+	RestobjectResourceModel
+
+	RequestEnvelope types.String `tfsdk:"request_envelope"`
+	RequestFormat   types.String `tfsdk:"request_format"`
+	RequestFiles    types.Map    `tfsdk:"request_files"`
+
+	SecretData        types.String `tfsdk:"secret_data"`
+	SecretDataVersion types.Int64  `tfsdk:"secret_data_version"`
+}
+
+// RestobjectIdentityModel is the identity of a restapi_object resource.
+type RestobjectIdentityModel struct {
+	Path         types.String `tfsdk:"path"`
+	ObjectID     types.String `tfsdk:"object_id"`
+	IDAttribute  types.String `tfsdk:"id_attribute"`
+	IDAttributes types.List   `tfsdk:"id_attributes"`
+	IDSeparator  types.String `tfsdk:"id_separator"`
 }
 
 type ReadSearch struct {
@@ -92,31 +132,36 @@ func (r *RestobjectResource) Schema(_ context.Context, _ resource.SchemaRequest,
 			},
 			"path": schema.StringAttribute{
 				Description: "The API path in addition to the base URL defined in the provider configuration, " +
-					"which represents objects of this type on the API server.",
+					"which represents objects of this type on the API server. For searches with `read_search`, " +
+					"the placeholders `{search_key}` and `{search_value}` are replaced by the URL-escaped search criteria. " +
+					"All paths support the placeholders `{data.<key>}` and `{response.<key>}`, which are replaced by " +
+					"the URL-escaped value at the slash-delimited key path in `data` or the last API response " +
+					"(`api_response_raw`, not available in `create_path`), and `{env.<name>}`, which is replaced by " +
+					"the URL-escaped value of the environment variable.",
 				Required: true,
 			},
 			"create_path": schema.StringAttribute{
 				Description: "Defaults to `path`. The API path that specifies where objects of this type " +
-					"are to be created (`POST`) on the API server. The string `{id}` is replaced by the Terraform ID " +
-					"of the object if the data contains the attribute `id_attribute`.",
+					"are to be created (`POST`) on the API server. The string `{id}` is replaced by the URL-escaped " +
+					"Terraform ID of the object if the data contains the attribute `id_attribute`.",
 				Optional: true,
 			},
 			"read_path": schema.StringAttribute{
 				Description: "Defaults to `path/{id}`. The API path that specifies where objects of this type " +
-					"can be read (`GET`) on the API server. The string `{id}` is replaced by the Terraform ID of " +
-					"the object.",
+					"can be read (`GET`) on the API server. The string `{id}` is replaced by the URL-escaped " +
+					"Terraform ID of the object.",
 				Optional: true,
 			},
 			"update_path": schema.StringAttribute{
 				Description: "Defaults to `path/{id}`. The API path that specifies where objects of this type " +
-					"can be updated (`PUT`) on the API server. The string `{id}` is replaced by the Terraform ID of " +
-					"the object.",
+					"can be updated (`PUT`) on the API server. The string `{id}` is replaced by the URL-escaped " +
+					"Terraform ID of the object.",
 				Optional: true,
 			},
 			"destroy_path": schema.StringAttribute{
 				Description: "Defaults to `path/{id}`. The API path that specifies where objects of this type " +
-					"can be deleted (`DELETE`) on the API server. The string `{id}` is replaced by the Terraform ID of " +
-					"the object.",
+					"can be deleted (`DELETE`) on the API server. The string `{id}` is replaced by the URL-escaped " +
+					"Terraform ID of the object.",
 				Optional: true,
 			},
 			"create_method": schema.StringAttribute{
@@ -144,11 +189,67 @@ func (r *RestobjectResource) Schema(_ context.Context, _ resource.SchemaRequest,
 					"Allows override of `id_attribute` (see `id_attribute` provider documentation) per data source.",
 				Optional: true,
 			},
+			"id_attributes": schema.ListAttribute{
+				ElementType: types.StringType,
+				Description: "List of attributes to build a composite ID for objects that are only uniquely " +
+					"addressable by multiple values. Takes precedence over `id_attribute`. The values are joined by " +
+					"`id_separator` to build the Terraform ID, and each component is available as placeholder " +
+					"`{id.<attribute>}` in the `*_path` attributes, e.g. `/orgs/{id.org}/teams/{id.slug}`.",
+				Optional: true,
+			},
+			"id_separator": schema.StringAttribute{
+				Description: "Defaults to `:`. Separator used to join the components of a composite ID " +
+					"(see `id_attributes`). The values of the `id_attributes` must not contain the separator.",
+				Optional: true,
+			},
+			"raw_id": schema.BoolAttribute{
+				Description: "Defaults to `false`. The object ID and its components are URL-escaped when replacing " +
+					"the `{id}` and `{id.<attribute>}` placeholders in the `*_path` attributes. Set to `true` to insert " +
+					"them unescaped, e.g. for APIs that expect IDs containing slashes as multiple path segments.",
+				Optional: true,
+			},
 			"object_id": schema.StringAttribute{
 				Description: "Defaults to the auto-generated `id` gathered during normal operations and `id_attribute`. " +
 					"Allows to set the ID manually. This is used in conjunction with the `*_path` attributes.",
 				Optional: true,
 			},
+			"request_envelope": schema.StringAttribute{
+				Description: "Defaults to `request_envelope` defined in the provider configuration. " +
+					"Allows override of `request_envelope` (see `request_envelope` provider documentation) per resource.",
+				Optional: true,
+			},
+			"response_key": schema.StringAttribute{
+				Description: "Defaults to `response_key` defined in the provider configuration. " +
+					"Allows override of `response_key` (see `response_key` provider documentation) per resource.",
+				Optional: true,
+			},
+			"jsonapi": schema.BoolAttribute{
+				Description: "Defaults to `jsonapi` defined in the provider configuration. " +
+					"Allows to enable or disable JSON:API mode (see `jsonapi` provider documentation) per resource.",
+				Optional: true,
+			},
+			"request_format": schema.StringAttribute{
+				Description: "Defaults to `request_format` defined in the provider configuration. " +
+					"Allows override of `request_format` (see `request_format` provider documentation) per resource.",
+				Optional: true,
+			},
+			"request_files": schema.MapAttribute{
+				ElementType: types.StringType,
+				Description: "Local files to attach to `multipart` request bodies when creating or updating the object, " +
+					"mapped by their form field name, e.g. `{ avatar = \"${path.module}/avatar.png\" }`. " +
+					"Changes of the file contents are not detected.",
+				Optional: true,
+			},
+			"response_format": schema.StringAttribute{
+				Description: "Defaults to `response_format` defined in the provider configuration. " +
+					"Allows override of `response_format` (see `response_format` provider documentation) per resource.",
+				Optional: true,
+			},
+			"xml_root": schema.StringAttribute{
+				Description: "Defaults to `xml_root` defined in the provider configuration. " +
+					"Allows override of `xml_root` (see `xml_root` provider documentation) per resource.",
+				Optional: true,
+			},
 			"data": schema.StringAttribute{
 				Description: "JSON object managed by the provider that holds information from the API response.",
 				Required:    true,
@@ -177,8 +278,10 @@ func (r *RestobjectResource) Schema(_ context.Context, _ resource.SchemaRequest,
 						Optional: true,
 					},
 					"query_string": schema.StringAttribute{
-						Description: "Defaults to `query_string`. Optional query string used for API read requests.",
-						Optional:    true,
+						Description: "Defaults to `query_string`. Optional query string used for API read requests. " +
+							"The placeholders `{search_key}` and `{search_value}` are replaced by the URL-escaped " +
+							"search criteria, which allows the API to filter the results server-side.",
+						Optional: true,
 					},
 				},
 			},
@@ -204,6 +307,21 @@ func (r *RestobjectResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Computed:    true,
 				Sensitive:   isDataSensitive,
 			},
+			"sensitive_keys": schema.ListAttribute{
+				ElementType: types.StringType,
+				Description: "List of slash-delimited key paths of sensitive values in the API response, e.g. " +
+					"`token` or `keys/*/secret`, where `*` matches all keys or array elements. The values are masked " +
+					"in `api_response` and `api_response_raw`, exposed by `sensitive_values` and redacted from the " +
+					"request and response bodies in the logs. Non-JSON bodies are redacted from the logs completely.",
+				Optional: true,
+			},
+			"sensitive_values": schema.MapAttribute{
+				ElementType: types.StringType,
+				Description: "The values of `sensitive_keys` in the API response, mapped by their key path. " +
+					"Like `api_response`, the value is the `golang fmt` representation of the value.",
+				Computed:  true,
+				Sensitive: true,
+			},
 			"update_data": schema.StringAttribute{
 				Optional:    true,
 				Description: "JSON object that is passed to update requests.",
@@ -214,6 +332,50 @@ func (r *RestobjectResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Description: "JSON object that is passed to destroy requests.",
 				Sensitive:   isDataSensitive,
 			},
+			"secret_data": schema.StringAttribute{
+				Description: "JSON object that is deep-merged into `data` and `update_data` of create and update requests. " +
+					"The value is write-only and never stored in the state, its keys are removed from `api_response` " +
+					"and skipped by the drift detection. Changes are only applied if `secret_data_version` is changed. " +
+					"Requires Terraform 1.11 or later.",
+				Optional:  true,
+				WriteOnly: true,
+				Sensitive: true,
+			},
+			"secret_data_version": schema.Int64Attribute{
+				Description: "Version of `secret_data`. Changing the version triggers an update of the object " +
+					"to apply the rotated secret data.",
+				Optional: true,
+			},
+		},
+	}
+}
+
+func (r *RestobjectResource) IdentitySchema(
+	_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse,
+) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"path": identityschema.StringAttribute{
+				Description:       "The API path of the collection the object belongs to, see `path`.",
+				RequiredForImport: true,
+			},
+			"object_id": identityschema.StringAttribute{
+				Description:       "The ID of the object, see `id`.",
+				RequiredForImport: true,
+			},
+			"id_attribute": identityschema.StringAttribute{
+				Description:       "The attribute of the object holding its ID, see `id_attribute`.",
+				OptionalForImport: true,
+			},
+			"id_attributes": identityschema.ListAttribute{
+				ElementType:       types.StringType,
+				Description:       "The attributes of the object building its composite ID, see `id_attributes`.",
+				OptionalForImport: true,
+			},
+			"id_separator": identityschema.StringAttribute{
+				Description:       "The separator of the composite ID components, see `id_separator`.",
+				OptionalForImport: true,
+			},
 		},
 	}
 }
@@ -245,7 +407,7 @@ func (r *RestobjectResource) Configure(
 
 //nolint:dupl
 func (r *RestobjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data RestobjectResourceModel
+	var data RestobjectWriteOnlyModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -254,8 +416,9 @@ func (r *RestobjectResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	objectOpts, diags := toObjectOptions(ctx, data)
+	objectOpts, diags := toWriteObjectOptions(ctx, data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setSecretData(ctx, req.Config, resp.Private, objectOpts)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -274,15 +437,16 @@ func (r *RestobjectResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	resp.Diagnostics.Append(mapFields(ctx, ro.Options, &data)...)
+	resp.Diagnostics.Append(mapFields(ctx, ro.Options, &data.RestobjectResourceModel)...)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+	resp.Diagnostics.Append(mapIdentity(ctx, data.RestobjectResourceModel, resp.Identity)...)
 }
 
 //nolint:dupl
 func (r *RestobjectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data RestobjectResourceModel
+	var data RestobjectWriteOnlyModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -291,8 +455,9 @@ func (r *RestobjectResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	objectOpts, diags := toObjectOptions(ctx, data)
+	objectOpts, diags := toWriteObjectOptions(ctx, data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(getSecretDataKeys(ctx, req.Private, objectOpts)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -311,15 +476,16 @@ func (r *RestobjectResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	resp.Diagnostics.Append(mapFields(ctx, ro.Options, &data)...)
+	resp.Diagnostics.Append(mapFields(ctx, ro.Options, &data.RestobjectResourceModel)...)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+	resp.Diagnostics.Append(mapIdentity(ctx, data.RestobjectResourceModel, resp.Identity)...)
 }
 
 //nolint:dupl
 func (r *RestobjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data RestobjectResourceModel
+	var data RestobjectWriteOnlyModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -328,8 +494,15 @@ func (r *RestobjectResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	objectOpts, diags := toObjectOptions(ctx, data)
+	objectOpts, diags := toWriteObjectOptions(ctx, data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setSecretData(ctx, req.Config, resp.Private, objectOpts)...)
+
+	// The planned API response is unknown, the prior one is only available from the state.
+	var apiResponseRaw types.String
+
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("api_response_raw"), &apiResponseRaw)...)
+	resp.Diagnostics.Append(setAPIResponse(objectOpts, apiResponseRaw)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -348,15 +521,16 @@ func (r *RestobjectResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	resp.Diagnostics.Append(mapFields(ctx, ro.Options, &data)...)
+	resp.Diagnostics.Append(mapFields(ctx, ro.Options, &data.RestobjectResourceModel)...)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+	resp.Diagnostics.Append(mapIdentity(ctx, data.RestobjectResourceModel, resp.Identity)...)
 }
 
 //nolint:dupl
 func (r *RestobjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data RestobjectResourceModel
+	var data RestobjectWriteOnlyModel
 
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -365,7 +539,7 @@ func (r *RestobjectResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	objectOpts, diags := toObjectOptions(ctx, data)
+	objectOpts, diags := toWriteObjectOptions(ctx, data)
 	resp.Diagnostics.Append(diags...)
 
 	if resp.Diagnostics.HasError() {
@@ -385,7 +559,7 @@ func (r *RestobjectResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	resp.Diagnostics.Append(mapFields(ctx, ro.Options, &data)...)
+	resp.Diagnostics.Append(mapFields(ctx, ro.Options, &data.RestobjectResourceModel)...)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
@@ -394,32 +568,31 @@ func (r *RestobjectResource) Delete(ctx context.Context, req resource.DeleteRequ
 func (r *RestobjectResource) ImportState(
 	ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse,
 ) {
-	var diags diag.Diagnostics
-
-	id, path, err := utils.ParseImportPath(req.ID)
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to import state", err.Error())
-
-		return
+	var (
+		data     RestobjectResourceModel
+		dataKeys []string
+		diags    diag.Diagnostics
+	)
+
+	if req.ID == "" && req.Identity != nil {
+		data, diags = toIdentityImportModel(ctx, req.Identity)
+	} else {
+		data, dataKeys, diags = toImportModel(ctx, req.ID)
 	}
 
-	readSearchAttrTypes := map[string]attr.Type{
-		"search_key":   types.StringType,
-		"search_value": types.StringType,
-		"result_key":   types.StringType,
-		"query_string": types.StringType,
-	}
+	resp.Diagnostics.Append(diags...)
 
-	data := RestobjectResourceModel{
-		ID:   types.StringValue(id),
-		Data: types.StringValue(fmt.Sprintf(`{ "id": "%s" }`, id)),
-		Path: types.StringValue(path),
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	data.ReadSearch = types.ObjectNull(readSearchAttrTypes)
 
 	objectOpts, diags := toObjectOptions(ctx, data)
 	resp.Diagnostics.Append(diags...)
 
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	ro, err := restobject.New(r.client, objectOpts)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to create API client", err.Error())
@@ -433,10 +606,237 @@ func (r *RestobjectResource) ImportState(
 		return
 	}
 
+	if ro.Options.ID == "" {
+		resp.Diagnostics.AddError("Failed to import state", fmt.Sprintf("object '%s' not found", req.ID))
+
+		return
+	}
+
+	// Populate the data from the live object, so the first plan after the
+	// import does not rewrite the whole object.
+	if err := setImportData(ro.Options, dataKeys); err != nil {
+		resp.Diagnostics.AddError("Failed to import state", err.Error())
+
+		return
+	}
+
 	resp.Diagnostics.Append(mapFields(ctx, ro.Options, &data)...)
 
 	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, newWriteOnlyModel(data))...)
+	resp.Diagnostics.Append(mapIdentity(ctx, data, resp.Identity)...)
+}
+
+// setSecretData reads the write-only secret data from the configuration into
+// the object options and stores its keys in the private state, so they can be
+// excluded from the API response of subsequent reads.
+func setSecretData(
+	ctx context.Context, config tfsdk.Config, private privateData, opts *restobject.ObjectOptions,
+) diag.Diagnostics {
+	var secretData types.String
+
+	diags := config.GetAttribute(ctx, path.Root("secret_data"), &secretData)
+	if diags.HasError() {
+		return diags
+	}
+
+	// Remove the keys of previously configured secret data.
+	if secretData.IsNull() || secretData.IsUnknown() {
+		diags.Append(private.SetKey(ctx, secretDataKeysKey, nil)...)
+
+		return diags
+	}
+
+	if err := json.Unmarshal([]byte(secretData.ValueString()), &opts.SecretData); err != nil {
+		diags.AddError("Can not decode secret_data", err.Error())
+
+		return diags
+	}
+
+	keys, err := json.Marshal(utils.ClearMaps(opts.SecretData))
+	if err != nil {
+		diags.AddError("Can not encode secret_data keys", err.Error())
+
+		return diags
+	}
+
+	diags.Append(private.SetKey(ctx, secretDataKeysKey, keys)...)
+
+	return diags
+}
+
+// getSecretDataKeys reads the keys of the secret data from the private state
+// into the object options.
+func getSecretDataKeys(ctx context.Context, private privateData, opts *restobject.ObjectOptions) diag.Diagnostics {
+	keys, diags := private.GetKey(ctx, secretDataKeysKey)
+	if diags.HasError() || len(keys) == 0 {
+		return diags
+	}
+
+	if err := json.Unmarshal(keys, &opts.SecretData); err != nil {
+		diags.AddError("Can not decode secret_data keys", err.Error())
+	}
+
+	return diags
+}
+
+// setImportData sets the object data to the keys of the API response. The API
+// response is already filtered by the response_filter of the provider, all
+// keys are used if no keys are given.
+func setImportData(opts *restobject.ObjectOptions, keys []string) error {
+	data, _, err := utils.FilterJSONString(opts.APIResponseRaw, keys, true)
+	if err != nil {
+		return err
+	}
+
+	opts.Data = data
+
+	return nil
+}
+
+// newImportModel returns an empty resource model with typed null values
+// for all collection attributes.
+func newImportModel() RestobjectResourceModel {
+	data := RestobjectResourceModel{}
+	data.ReadSearch = types.ObjectNull(map[string]attr.Type{
+		"search_key":   types.StringType,
+		"search_value": types.StringType,
+		"result_key":   types.StringType,
+		"query_string": types.StringType,
+	})
+	data.IDAttributes = types.ListNull(types.StringType)
+	data.SensitiveKeys = types.ListNull(types.StringType)
+
+	return data
+}
+
+// newWriteOnlyModel wraps a resource model without request body attributes,
+// e.g. of an imported object, into the model of the resource state.
+func newWriteOnlyModel(data RestobjectResourceModel) RestobjectWriteOnlyModel {
+	return RestobjectWriteOnlyModel{
+		RestobjectResourceModel: data,
+		RequestFiles:            types.MapNull(types.StringType),
+	}
+}
+
+// toIdentityImportModel builds the resource model from the identity of an
+// import block.
+func toIdentityImportModel(
+	ctx context.Context, identity *tfsdk.ResourceIdentity,
+) (RestobjectResourceModel, diag.Diagnostics) {
+	var identityData RestobjectIdentityModel
+
+	data := newImportModel()
+
+	diags := identity.Get(ctx, &identityData)
+	if diags.HasError() {
+		return data, diags
+	}
+
+	data.ID = identityData.ObjectID
+	data.Path = types.StringValue("/" + utils.SanitizePath(identityData.Path.ValueString()))
+	data.IDAttribute = identityData.IDAttribute
+	data.IDSeparator = identityData.IDSeparator
+
+	if !identityData.IDAttributes.IsNull() {
+		data.IDAttributes = identityData.IDAttributes
+	}
+
+	return data, diags
+}
+
+// toImportModel builds the resource model from the import ID and returns it
+// together with the keys of the live object to populate the data with.
+// Besides the API path of the object, the import ID can be an extended import
+// ID (see utils.ParseImportOptions).
+func toImportModel(ctx context.Context, importID string) (RestobjectResourceModel, []string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	data := newImportModel()
+	readSearchAttrTypes := data.ReadSearch.AttributeTypes(ctx)
+
+	importOpts, ok, err := utils.ParseImportOptions(importID)
+	if err != nil {
+		diags.AddError("Failed to import state", err.Error())
+
+		return data, nil, diags
+	}
+
+	if ok {
+		data.ID = types.StringValue(importOpts.ID)
+		data.Path = types.StringValue("/" + utils.SanitizePath(importOpts.Path))
+
+		if importOpts.ReadPath != "" {
+			data.GetPath = types.StringValue(importOpts.ReadPath)
+		}
+
+		if importOpts.IDAttribute != "" {
+			data.IDAttribute = types.StringValue(importOpts.IDAttribute)
+		}
+
+		if len(importOpts.IDAttributes) > 0 {
+			var listDiags diag.Diagnostics
+
+			data.IDAttributes, listDiags = types.ListValueFrom(ctx, types.StringType, importOpts.IDAttributes)
+			diags.Append(listDiags...)
+		}
+
+		if importOpts.IDSeparator != "" {
+			data.IDSeparator = types.StringValue(importOpts.IDSeparator)
+		}
+
+		if importOpts.QueryString != "" {
+			data.QueryString = types.StringValue(importOpts.QueryString)
+		}
+
+		if importOpts.ReadSearch != nil {
+			var objDiags diag.Diagnostics
+
+			data.ReadSearch, objDiags = types.ObjectValue(readSearchAttrTypes, map[string]attr.Value{
+				"search_key":   types.StringValue(importOpts.ReadSearch.SearchKey),
+				"search_value": types.StringValue(importOpts.ReadSearch.SearchValue),
+				"result_key":   types.StringValue(importOpts.ReadSearch.ResultKey),
+				"query_string": types.StringValue(importOpts.ReadSearch.QueryString),
+			})
+			diags.Append(objDiags...)
+		}
+
+		return data, importOpts.DataKeys, diags
+	}
+
+	id, path, err := utils.ParseImportPath(importID)
+	if err != nil {
+		diags.AddError("Failed to import state", err.Error())
+
+		return data, nil, diags
+	}
+
+	data.ID = types.StringValue(id)
+	data.Path = types.StringValue(path)
+
+	return data, nil, diags
+}
+
+// toWriteObjectOptions returns the object options of the resource, including
+// the attributes of the request body.
+func toWriteObjectOptions(
+	ctx context.Context, data RestobjectWriteOnlyModel,
+) (*restobject.ObjectOptions, diag.Diagnostics) {
+	objectOpts, diags := toObjectOptions(ctx, data.RestobjectResourceModel)
+
+	if !data.RequestEnvelope.IsNull() && !data.RequestEnvelope.IsUnknown() {
+		objectOpts.RequestEnvelope = data.RequestEnvelope.ValueString()
+	}
+
+	if !data.RequestFormat.IsNull() && !data.RequestFormat.IsUnknown() {
+		objectOpts.RequestFormat = data.RequestFormat.ValueString()
+	}
+
+	if !data.RequestFiles.IsNull() && !data.RequestFiles.IsUnknown() {
+		diags.Append(data.RequestFiles.ElementsAs(ctx, &objectOpts.RequestFiles, false)...)
+	}
+
+	return objectOpts, diags
 }
 
 //nolint:gocyclo,gocognit
@@ -521,8 +921,36 @@ func toObjectOptions(ctx context.Context, data RestobjectResourceModel) (*restob
 		objectOpts.IDAttribute = data.IDAttribute.ValueString()
 	}
 
-	if !data.IDAttribute.IsNull() && !data.IDAttribute.IsUnknown() {
-		objectOpts.IDAttribute = data.IDAttribute.ValueString()
+	if !data.IDAttributes.IsNull() && !data.IDAttributes.IsUnknown() {
+		diags.Append(data.IDAttributes.ElementsAs(ctx, &objectOpts.IDAttributes, false)...)
+	}
+
+	if !data.IDSeparator.IsNull() && !data.IDSeparator.IsUnknown() {
+		objectOpts.IDSeparator = data.IDSeparator.ValueString()
+	}
+
+	if !data.RawID.IsNull() && !data.RawID.IsUnknown() {
+		objectOpts.RawID = data.RawID.ValueBool()
+	}
+
+	if !data.ResponseKey.IsNull() && !data.ResponseKey.IsUnknown() {
+		objectOpts.ResponseKey = data.ResponseKey.ValueString()
+	}
+
+	if !data.JSONAPI.IsNull() && !data.JSONAPI.IsUnknown() {
+		objectOpts.JSONAPI = data.JSONAPI.ValueBoolPointer()
+	}
+
+	if !data.SensitiveKeys.IsNull() && !data.SensitiveKeys.IsUnknown() {
+		diags.Append(data.SensitiveKeys.ElementsAs(ctx, &objectOpts.SensitiveKeys, false)...)
+	}
+
+	if !data.ResponseFormat.IsNull() && !data.ResponseFormat.IsUnknown() {
+		objectOpts.ResponseFormat = data.ResponseFormat.ValueString()
+	}
+
+	if !data.XMLRoot.IsNull() && !data.XMLRoot.IsUnknown() {
+		objectOpts.XMLRoot = data.XMLRoot.ValueString()
 	}
 
 	if !data.Data.IsNull() && !data.Data.IsUnknown() {
@@ -532,9 +960,29 @@ func toObjectOptions(ctx context.Context, data RestobjectResourceModel) (*restob
 		}
 	}
 
+	diags.Append(setAPIResponse(objectOpts, data.APIResponseRaw)...)
+
 	return objectOpts, diags
 }
 
+// setAPIResponse restores the API response of the last read from the raw API
+// response, e.g. to resolve the `{response.<key>}` path placeholders.
+func setAPIResponse(opts *restobject.ObjectOptions, raw types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if raw.IsNull() || raw.IsUnknown() || raw.ValueString() == "" {
+		return diags
+	}
+
+	if err := json.Unmarshal([]byte(raw.ValueString()), &opts.APIResponse); err != nil {
+		diags.AddError("Can not parse attribute", fmt.Sprintf("%s: %v", err, raw))
+	}
+
+	opts.APIResponseRaw = raw.ValueString()
+
+	return diags
+}
+
 func mapFields(ctx context.Context, opts *restobject.ObjectOptions, model *RestobjectResourceModel) diag.Diagnostics {
 	var diags diag.Diagnostics
 
@@ -556,5 +1004,32 @@ func mapFields(ctx context.Context, opts *restobject.ObjectOptions, model *Resto
 	model.APIResponseRaw = types.StringValue(opts.APIResponseRaw)
 	model.CreateResponseRaw = types.StringValue(opts.CreateResponseRaw)
 
+	values := make(map[string]string)
+	for k, v := range opts.SensitiveValues {
+		values[k] = fmt.Sprintf("%v", v)
+	}
+
+	sensitiveValues, valueDiags := types.MapValueFrom(ctx, types.StringType, values)
+	diags.Append(valueDiags...)
+	model.SensitiveValues = sensitiveValues
+
 	return diags
 }
+
+// mapIdentity sets the resource identity from the resource model. The identity
+// is left unchanged if the resource does not support it or the object is gone.
+func mapIdentity(
+	ctx context.Context, model RestobjectResourceModel, identity *tfsdk.ResourceIdentity,
+) diag.Diagnostics {
+	if identity == nil || model.ID.ValueString() == "" {
+		return nil
+	}
+
+	return identity.Set(ctx, RestobjectIdentityModel{
+		Path:         model.Path,
+		ObjectID:     model.ID,
+		IDAttribute:  model.IDAttribute,
+		IDAttributes: model.IDAttributes,
+		IDSeparator:  model.IDSeparator,
+	})
+}
@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restobject"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils/mockclient"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToImportModel(t *testing.T) {
+	tests := []struct {
+		name             string
+		id               string
+		wantID           string
+		wantPath         string
+		wantIDAttributes []string
+		wantIDSeparator  string
+	}{
+		{
+			name:     "path",
+			id:       "/api/things/123",
+			wantID:   "123",
+			wantPath: "/api/things",
+		},
+		{
+			name:     "base64 id",
+			id:       "/api/things/abc==",
+			wantID:   "abc==",
+			wantPath: "/api/things",
+		},
+		{
+			name:     "key value id",
+			id:       "/api/teams/org=acme&slug=devs",
+			wantID:   "org=acme&slug=devs",
+			wantPath: "/api/teams",
+		},
+		{
+			name:     "verbatim id",
+			id:       "/api/files|path/to/file.txt",
+			wantID:   "path/to/file.txt",
+			wantPath: "/api/files",
+		},
+		{
+			name:             "composite id",
+			id:               "path=/api/repos&id=acme:repo&id_attributes=owner/login,name",
+			wantID:           "acme:repo",
+			wantPath:         "/api/repos",
+			wantIDAttributes: []string{"owner/login", "name"},
+		},
+		{
+			name:             "composite id with separator",
+			id:               `{"path": "/api/teams", "id": "acme/devs", "id_attributes": ["org", "slug"], "id_separator": "/"}`,
+			wantID:           "acme/devs",
+			wantPath:         "/api/teams",
+			wantIDAttributes: []string{"org", "slug"},
+			wantIDSeparator:  "/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _, diags := toImportModel(t.Context(), tt.id)
+			assert.False(t, diags.HasError(), diags)
+
+			assert.Equal(t, tt.wantID, data.ID.ValueString())
+			assert.Equal(t, tt.wantPath, data.Path.ValueString())
+			assert.True(t, data.Data.IsNull())
+			assert.Equal(t, tt.wantIDSeparator, data.IDSeparator.ValueString())
+
+			var idAttributes []string
+
+			if !data.IDAttributes.IsNull() {
+				data.IDAttributes.ElementsAs(t.Context(), &idAttributes, false)
+			}
+
+			assert.Equal(t, tt.wantIDAttributes, idAttributes)
+		})
+	}
+}
+
+func TestImportCompositeID(t *testing.T) {
+	client := mockclient.New(t, &restclient.ClientOptions{})
+
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://restapi.local/repos/acme/repo",
+		httpmock.NewStringResponder(http.StatusOK, `{"owner": {"login": "acme"}, "name": "repo"}`),
+	)
+
+	data, _, diags := toImportModel(t.Context(),
+		"path=/api/repos&id=acme:repo&id_attributes=owner/login,name&read_path=/repos/{id.owner/login}/{id.name}")
+	assert.False(t, diags.HasError(), diags)
+
+	opts, diags := toObjectOptions(t.Context(), data)
+	assert.False(t, diags.HasError(), diags)
+
+	ro, err := restobject.New(client, opts)
+	assert.NoError(t, err)
+
+	assert.NoError(t, ro.Read(t.Context()))
+	assert.Equal(t, "acme:repo", ro.Options.ID)
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+func TestRestobjectResponsePlaceholder(t *testing.T) {
+	var requests []string
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "1", "project": {"id": "p 1"}, "name": "foo"}`))
+	}))
+	t.Cleanup(api.Close)
+
+	server := newTestServer(t, api.URL)
+	typ := server.schemas.ResourceSchemas["restapi_object"].ValueType()
+
+	attrs := map[string]tftypes.Value{
+		"path":             tftypes.NewValue(tftypes.String, "/things"),
+		"read_path":        tftypes.NewValue(tftypes.String, "/projects/{response.project/id}/things/{id}"),
+		"update_path":      tftypes.NewValue(tftypes.String, "/projects/{response.project/id}/things/{id}"),
+		"destroy_path":     tftypes.NewValue(tftypes.String, "/projects/{response.project/id}/things/{id}"),
+		"id":               tftypes.NewValue(tftypes.String, "1"),
+		"data":             tftypes.NewValue(tftypes.String, `{"id": "1", "name": "foo"}`),
+		"api_response_raw": tftypes.NewValue(tftypes.String, `{"id": "1", "project": {"id": "p 1"}, "name": "foo"}`),
+	}
+	state := server.resourceValue(t, "restapi_object", attrs)
+
+	readResp, err := server.ReadResource(t.Context(), &tfprotov6.ReadResourceRequest{
+		TypeName:     "restapi_object",
+		CurrentState: state,
+	})
+	assert.NoError(t, err)
+	assertNoDiagnostics(t, readResp.Diagnostics)
+
+	// The planned API response is unknown, the prior one has to be taken from the state.
+	planAttrs := maps.Clone(attrs)
+	planAttrs["data"] = tftypes.NewValue(tftypes.String, `{"id": "1", "name": "bar"}`)
+	planAttrs["api_response_raw"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+
+	updateResp, err := server.ApplyResourceChange(t.Context(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     "restapi_object",
+		PriorState:   state,
+		PlannedState: server.resourceValue(t, "restapi_object", planAttrs),
+		Config:       server.resourceValue(t, "restapi_object", nil),
+	})
+	assert.NoError(t, err)
+	assertNoDiagnostics(t, updateResp.Diagnostics)
+	assert.JSONEq(t, `{"id": "1", "project": {"id": "p 1"}, "name": "foo"}`,
+		stateAttribute(t, typ, updateResp.NewState, "api_response_raw"))
+
+	deleteResp, err := server.ApplyResourceChange(t.Context(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     "restapi_object",
+		PriorState:   state,
+		PlannedState: server.resourceNull(t, "restapi_object"),
+		Config:       server.resourceValue(t, "restapi_object", nil),
+	})
+	assert.NoError(t, err)
+	assertNoDiagnostics(t, deleteResp.Diagnostics)
+
+	assert.Equal(t, []string{
+		"GET /projects/p%201/things/1",
+		"PUT /projects/p%201/things/1",
+		"GET /projects/p%201/things/1",
+		"DELETE /projects/p%201/things/1",
+	}, requests)
+}
+
+func TestRestobjectResponsePlaceholderCreate(t *testing.T) {
+	server := newTestServer(t, "https://restapi.local")
+
+	resp, err := server.ApplyResourceChange(t.Context(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:   "restapi_object",
+		PriorState: server.resourceNull(t, "restapi_object"),
+		PlannedState: server.resourceValue(t, "restapi_object", map[string]tftypes.Value{
+			"path":        tftypes.NewValue(tftypes.String, "/things"),
+			"create_path": tftypes.NewValue(tftypes.String, "/projects/{response.project/id}/things"),
+			"data":        tftypes.NewValue(tftypes.String, `{"id": "1"}`),
+		}),
+		Config: server.resourceValue(t, "restapi_object", nil),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Diagnostics, 1)
+	assert.Contains(t, resp.Diagnostics[0].Detail, "placeholder '{response.<key>}' not supported in create_path")
+}
+
+func TestImportIdentity(t *testing.T) {
+	var requests []string
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"owner": {"login": "acme"}, "name": "repo", "size": 1}`))
+	}))
+	t.Cleanup(api.Close)
+
+	server := newTestServer(t, api.URL)
+	typ := server.schemas.ResourceSchemas["restapi_object"].ValueType()
+	identityType := server.identities.IdentitySchemas["restapi_object"].ValueType()
+
+	idAttributes := tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+		tftypes.NewValue(tftypes.String, "owner/login"),
+		tftypes.NewValue(tftypes.String, "name"),
+	})
+
+	resp, err := server.ImportResourceState(t.Context(), &tfprotov6.ImportResourceStateRequest{
+		TypeName: "restapi_object",
+		Identity: server.identityValue(t, "restapi_object", map[string]tftypes.Value{
+			"path":          tftypes.NewValue(tftypes.String, "/repos"),
+			"object_id":     tftypes.NewValue(tftypes.String, "acme/repo"),
+			"id_attributes": idAttributes,
+			"id_separator":  tftypes.NewValue(tftypes.String, "/"),
+		}),
+	})
+	assert.NoError(t, err)
+	assertNoDiagnostics(t, resp.Diagnostics)
+	assert.Len(t, resp.ImportedResources, 1)
+
+	imported := resp.ImportedResources[0]
+
+	assert.Equal(t, "acme/repo", stateAttribute(t, typ, imported.State, "id"))
+	assert.Equal(t, "/", stateAttribute(t, typ, imported.State, "id_separator"))
+	assert.Equal(t, []string{"GET /repos/acme%2Frepo"}, requests)
+
+	identity, err := imported.Identity.IdentityData.Unmarshal(identityType)
+	assert.NoError(t, err)
+
+	var attrs map[string]tftypes.Value
+
+	assert.NoError(t, identity.As(&attrs))
+	assert.True(t, attrs["id_attributes"].Equal(idAttributes))
+	assert.True(t, attrs["id_separator"].Equal(tftypes.NewValue(tftypes.String, "/")))
+}
+
+func TestImportIdentityID(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"uuid": "abc", "name": "foo"}`))
+	}))
+	t.Cleanup(api.Close)
+
+	server := newTestServer(t, api.URL)
+	typ := server.schemas.ResourceSchemas["restapi_object"].ValueType()
+
+	resp, err := server.ImportResourceState(t.Context(), &tfprotov6.ImportResourceStateRequest{
+		TypeName: "restapi_object",
+		Identity: server.identityValue(t, "restapi_object", map[string]tftypes.Value{
+			"path":         tftypes.NewValue(tftypes.String, "/things"),
+			"object_id":    tftypes.NewValue(tftypes.String, "abc"),
+			"id_attribute": tftypes.NewValue(tftypes.String, "uuid"),
+		}),
+	})
+	assert.NoError(t, err)
+	assertNoDiagnostics(t, resp.Diagnostics)
+	assert.Len(t, resp.ImportedResources, 1)
+	assert.Equal(t, "abc", stateAttribute(t, typ, resp.ImportedResources[0].State, "id"))
+	assert.Equal(t, "uuid", stateAttribute(t, typ, resp.ImportedResources[0].State, "id_attribute"))
+}
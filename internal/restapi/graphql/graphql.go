@@ -0,0 +1,130 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+)
+
+// DefaultPath is the API path of the GraphQL endpoint if none is configured.
+const DefaultPath = "/graphql"
+
+var (
+	ErrGraphQL         = errors.New("graphql request failed")
+	ErrInvalidResponse = errors.New("invalid graphql response")
+)
+
+// Request is the body of a GraphQL request.
+type Request struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+// Response is the body of a GraphQL response.
+type Response struct {
+	Data   map[string]any `json:"data"`
+	Errors Errors         `json:"errors"`
+
+	// Raw is the unmodified response body.
+	Raw string `json:"-"`
+}
+
+// Error is a single entry of the errors array of a GraphQL response.
+type Error struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+func (e Error) Error() string {
+	if len(e.Path) == 0 {
+		return e.Message
+	}
+
+	parts := make([]string, 0, len(e.Path))
+	for _, part := range e.Path {
+		parts = append(parts, fmt.Sprintf("%v", part))
+	}
+
+	return fmt.Sprintf("%s (path: %s)", e.Message, strings.Join(parts, "/"))
+}
+
+// Errors is the errors array of a GraphQL response.
+type Errors []Error
+
+func (e Errors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Execute sends the GraphQL operation with the given variables to the path
+// using the REST client. GraphQL errors are returned as Errors, even if the
+// server responded with HTTP 200.
+func Execute(
+	ctx context.Context, client *restclient.RestClient, path, query string, variables map[string]any,
+) (*Response, error) {
+	body, err := json.Marshal(Request{Query: query, Variables: variables})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrGraphQL, err)
+	}
+
+	resp, err := client.Do(ctx, http.MethodPost, path, string(body), restclient.ContentTypeJSON)
+
+	// Servers may respond with GraphQL errors and a non-2xx status code, which
+	// are more helpful than the status code.
+	result := &Response{Raw: resp.Body}
+	if decodeErr := json.Unmarshal([]byte(resp.Body), result); decodeErr != nil {
+		if err != nil {
+			return result, err
+		}
+
+		return result, fmt.Errorf("%w: %w", ErrInvalidResponse, decodeErr)
+	}
+
+	if len(result.Errors) > 0 {
+		return result, fmt.Errorf("%w: %w", ErrGraphQL, result.Errors)
+	}
+
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// resultAt returns the value at the slash-delimited path in the response data.
+// An empty path returns the data itself. The result is null if a parent object
+// on the path is null, as GraphQL nulls the parents of fields that can not be
+// resolved, e.g. of a deleted object.
+func resultAt(data map[string]any, path string) (any, error) {
+	path = utils.SanitizePath(path)
+	if path == "" {
+		return data, nil
+	}
+
+	result, err := utils.GetObjectAtKey(data, path)
+	if err == nil {
+		return result, nil
+	}
+
+	parts := strings.Split(path, "/")
+
+	for i := 1; i < len(parts); i++ {
+		parent, parentErr := utils.GetObjectAtKey(data, strings.Join(parts[:i], "/"))
+		if parentErr == nil && parent == nil {
+			return nil, nil //nolint:nilnil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %w", ErrInvalidResponse, err)
+}
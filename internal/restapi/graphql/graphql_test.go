@@ -0,0 +1,103 @@
+package graphql
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils/mockclient"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const testURL = "https://restapi.local/graphql"
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		want       map[string]any
+		wantErr    error
+		wantErrors Errors
+	}{
+		{
+			name:   "data",
+			status: http.StatusOK,
+			body:   `{"data": {"user": {"id": "1"}}}`,
+			want:   map[string]any{"user": map[string]any{"id": "1"}},
+		},
+		{
+			name:    "errors on success",
+			status:  http.StatusOK,
+			body:    `{"data": {"user": null}, "errors": [{"message": "forbidden", "path": ["user", 0]}]}`,
+			wantErr: ErrGraphQL,
+			wantErrors: Errors{
+				{Message: "forbidden", Path: []any{"user", float64(0)}},
+			},
+		},
+		{
+			name:    "errors on failure",
+			status:  http.StatusBadRequest,
+			body:    `{"errors": [{"message": "syntax error"}, {"message": "unknown field"}]}`,
+			wantErr: ErrGraphQL,
+			wantErrors: Errors{
+				{Message: "syntax error"},
+				{Message: "unknown field"},
+			},
+		},
+		{
+			name:    "failure without body",
+			status:  http.StatusBadGateway,
+			body:    "bad gateway",
+			wantErr: restclient.ErrUnexpectedResponseCode,
+		},
+		{
+			name:    "invalid response",
+			status:  http.StatusOK,
+			body:    "<html></html>",
+			wantErr: ErrInvalidResponse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := mockclient.New(t, &restclient.ClientOptions{})
+
+			httpmock.RegisterResponder(http.MethodPost, testURL, func(req *http.Request) (*http.Response, error) {
+				b, _ := io.ReadAll(req.Body)
+				assert.JSONEq(t, `{"query": "query { user }", "variables": {"id": "1"}}`, string(b))
+
+				return httpmock.NewStringResponse(tt.status, tt.body), nil
+			})
+
+			got, err := Execute(t.Context(), client, DefaultPath, "query { user }", map[string]any{"id": "1"})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				var gqlErrors Errors
+				if tt.wantErrors != nil && assert.True(t, errors.As(err, &gqlErrors)) {
+					assert.Equal(t, tt.wantErrors, gqlErrors)
+				}
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.Data)
+			assert.Equal(t, tt.body, got.Raw)
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	err := Errors{
+		{Message: "forbidden", Path: []any{"users", float64(1), "email"}},
+		{Message: "rate limited"},
+	}
+
+	assert.Equal(t, "forbidden (path: users/1/email); rate limited", err.Error())
+}
@@ -0,0 +1,231 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DefaultIDVariable is the name of the variable that holds the object ID
+// in read, update and delete operations.
+const DefaultIDVariable = "id"
+
+var (
+	ErrInvalidMutationOptions = errors.New("invalid mutation options")
+	ErrCreateMutation         = errors.New("failed to create object")
+	ErrReadQuery              = errors.New("failed to read object")
+	ErrUpdateMutation         = errors.New("failed to update object")
+	ErrDeleteMutation         = errors.New("failed to delete object")
+)
+
+// Mutation is the state holding struct for a restapi_graphql_mutation resource.
+type Mutation struct {
+	client  *restclient.RestClient
+	Options *MutationOptions
+}
+
+type MutationOptions struct {
+	Path             string
+	CreateMutation   string
+	ReadQuery        string
+	UpdateMutation   string
+	DeleteMutation   string
+	Variables        map[string]any
+	IDPath           string
+	IDVariable       string
+	CreateResultPath string
+	ReadResultPath   string
+	UpdateResultPath string
+
+	// Set internally
+	ID          string
+	Result      any // Result as extracted from the last response
+	ResponseRaw string
+}
+
+// NewMutation creates a new Mutation instance with the given client and options.
+// It sets default values for the options if they are not provided.
+func NewMutation(client *restclient.RestClient, opts *MutationOptions) (*Mutation, error) {
+	if opts.Path == "" {
+		opts.Path = DefaultPath
+	}
+
+	if opts.IDVariable == "" {
+		opts.IDVariable = DefaultIDVariable
+	}
+
+	if opts.CreateMutation == "" {
+		return nil, fmt.Errorf("%w: create mutation not set", ErrInvalidMutationOptions)
+	}
+
+	if opts.IDPath == "" {
+		return nil, fmt.Errorf("%w: id path not set", ErrInvalidMutationOptions)
+	}
+
+	return &Mutation{client: client, Options: opts}, nil
+}
+
+// Create sends the create mutation and gathers the object ID from the ID path
+// of the response data.
+func (m *Mutation) Create(ctx context.Context) (err error) {
+	ctx, span := m.startSpan(ctx, "create")
+	defer func() { m.endSpan(ctx, span, err) }()
+
+	opts := m.Options
+
+	resp, err := Execute(ctx, m.client, opts.Path, opts.CreateMutation, opts.Variables)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCreateMutation, err)
+	}
+
+	id, err := utils.GetStringAtKey(resp.Data, opts.IDPath)
+	if err != nil {
+		return fmt.Errorf("%w: failed to find id at '%s': %w", ErrCreateMutation, opts.IDPath, err)
+	}
+
+	opts.ID = id
+
+	if err := m.setResult(resp, opts.CreateResultPath); err != nil {
+		return fmt.Errorf("%w: %w", ErrCreateMutation, err)
+	}
+
+	return nil
+}
+
+// Read sends the read query if configured. The object ID is removed if the
+// result of the query is null, as the object does not exist (anymore).
+func (m *Mutation) Read(ctx context.Context) (err error) {
+	ctx, span := m.startSpan(ctx, "read")
+	defer func() { m.endSpan(ctx, span, err) }()
+
+	opts := m.Options
+
+	if opts.ID == "" {
+		return fmt.Errorf("%w: id not set", ErrReadQuery)
+	}
+
+	if opts.ReadQuery == "" {
+		tflog.Debug(ctx, "no read query set: keeping the result of the last mutation")
+
+		return nil
+	}
+
+	resp, err := Execute(ctx, m.client, opts.Path, opts.ReadQuery, m.variables())
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrReadQuery, err)
+	}
+
+	if err := m.setResult(resp, opts.ReadResultPath); err != nil {
+		return fmt.Errorf("%w: %w", ErrReadQuery, err)
+	}
+
+	if opts.Result == nil {
+		tflog.Warn(ctx, fmt.Sprintf("object '%s' not found: removing from state", opts.ID))
+
+		opts.ID = ""
+	}
+
+	return nil
+}
+
+// Update sends the update mutation if configured.
+func (m *Mutation) Update(ctx context.Context) (err error) {
+	ctx, span := m.startSpan(ctx, "update")
+	defer func() { m.endSpan(ctx, span, err) }()
+
+	opts := m.Options
+
+	if opts.ID == "" {
+		return fmt.Errorf("%w: id not set", ErrUpdateMutation)
+	}
+
+	if opts.UpdateMutation == "" {
+		tflog.Debug(ctx, "no update mutation set: skipping update")
+
+		return nil
+	}
+
+	resp, err := Execute(ctx, m.client, opts.Path, opts.UpdateMutation, m.variables())
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUpdateMutation, err)
+	}
+
+	if err := m.setResult(resp, opts.UpdateResultPath); err != nil {
+		return fmt.Errorf("%w: %w", ErrUpdateMutation, err)
+	}
+
+	return nil
+}
+
+// Delete sends the delete mutation if configured.
+func (m *Mutation) Delete(ctx context.Context) (err error) {
+	ctx, span := m.startSpan(ctx, "delete")
+	defer func() { m.endSpan(ctx, span, err) }()
+
+	opts := m.Options
+
+	if opts.ID == "" {
+		tflog.Warn(ctx, "attempt to delete an object that has no id set: assuming this is ok")
+
+		return nil
+	}
+
+	if opts.DeleteMutation == "" {
+		tflog.Warn(ctx, fmt.Sprintf("no delete mutation set: object '%s' is only removed from state", opts.ID))
+
+		return nil
+	}
+
+	if _, err := Execute(ctx, m.client, opts.Path, opts.DeleteMutation, m.variables()); err != nil {
+		return fmt.Errorf("%w: %w", ErrDeleteMutation, err)
+	}
+
+	return nil
+}
+
+// startSpan starts the span of an operation of the mutation, see
+// restclient.RestClient.StartSpan.
+func (m *Mutation) startSpan(ctx context.Context, operation string) (context.Context, trace.Span) {
+	return m.client.StartSpan(ctx, "graphql.mutation."+operation,
+		attribute.String("restapi.object.path", m.Options.Path),
+	)
+}
+
+// endSpan ends the span of an operation of the mutation with the ID of the
+// object, which might have been set by the operation.
+func (m *Mutation) endSpan(ctx context.Context, span trace.Span, err error) {
+	span.SetAttributes(attribute.String("restapi.object.id", m.Options.ID))
+	m.client.EndSpan(ctx, span, err)
+}
+
+// variables returns the configured variables with the object ID added.
+func (m *Mutation) variables() map[string]any {
+	variables := maps.Clone(m.Options.Variables)
+	if variables == nil {
+		variables = make(map[string]any)
+	}
+
+	variables[m.Options.IDVariable] = m.Options.ID
+
+	return variables
+}
+
+func (m *Mutation) setResult(resp *Response, path string) error {
+	result, err := resultAt(resp.Data, path)
+	if err != nil {
+		return err
+	}
+
+	m.Options.Result = result
+	m.Options.ResponseRaw = resp.Raw
+
+	return nil
+}
@@ -0,0 +1,273 @@
+package graphql
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils/mockclient"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMutation(t *testing.T, opts *MutationOptions) *Mutation {
+	t.Helper()
+
+	if opts.CreateMutation == "" {
+		opts.CreateMutation = "mutation($name: String!) { createUser(name: $name) { user { id name } } }"
+	}
+
+	if opts.IDPath == "" {
+		opts.IDPath = "createUser/user/id"
+	}
+
+	m, err := NewMutation(mockclient.New(t, &restclient.ClientOptions{}), opts)
+	assert.NoError(t, err)
+
+	return m
+}
+
+func TestNewMutation(t *testing.T) {
+	_, err := NewMutation(nil, &MutationOptions{IDPath: "createUser/id"})
+	assert.ErrorIs(t, err, ErrInvalidMutationOptions)
+
+	_, err = NewMutation(nil, &MutationOptions{CreateMutation: "mutation { createUser { id } }"})
+	assert.ErrorIs(t, err, ErrInvalidMutationOptions)
+
+	m, err := NewMutation(nil, &MutationOptions{CreateMutation: "mutation { createUser { id } }", IDPath: "createUser/id"})
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultPath, m.Options.Path)
+	assert.Equal(t, DefaultIDVariable, m.Options.IDVariable)
+}
+
+func TestMutationCreate(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       *MutationOptions
+		response   string
+		wantID     string
+		wantResult any
+		wantErr    error
+	}{
+		{
+			name:       "create",
+			opts:       &MutationOptions{CreateResultPath: "createUser/user"},
+			response:   `{"data": {"createUser": {"user": {"id": "1", "name": "foo"}}}}`,
+			wantID:     "1",
+			wantResult: map[string]any{"id": "1", "name": "foo"},
+		},
+		{
+			name:       "numeric id",
+			opts:       &MutationOptions{},
+			response:   `{"data": {"createUser": {"user": {"id": 42}}}}`,
+			wantID:     "42",
+			wantResult: map[string]any{"createUser": map[string]any{"user": map[string]any{"id": float64(42)}}},
+		},
+		{
+			name:     "id not found",
+			opts:     &MutationOptions{},
+			response: `{"data": {"createUser": null}}`,
+			wantErr:  ErrCreateMutation,
+		},
+		{
+			name:     "graphql errors",
+			opts:     &MutationOptions{},
+			response: `{"data": {"createUser": null}, "errors": [{"message": "name taken"}]}`,
+			wantErr:  ErrGraphQL,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []Request
+
+			tt.opts.Variables = map[string]any{"name": "foo"}
+			m := newTestMutation(t, tt.opts)
+			testutils.RegisterRecordingResponder(t, http.MethodPost, testURL, &requests, tt.response)
+
+			err := m.Create(t.Context())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, ErrCreateMutation)
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantID, m.Options.ID)
+			assert.Equal(t, tt.wantResult, m.Options.Result)
+			assert.Equal(t, []Request{{Query: m.Options.CreateMutation, Variables: map[string]any{"name": "foo"}}}, requests)
+		})
+	}
+}
+
+func TestMutationRead(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       *MutationOptions
+		response   string
+		wantID     string
+		wantResult any
+		wantErr    error
+	}{
+		{
+			name: "read",
+			opts: &MutationOptions{
+				ReadQuery:      "query($uid: ID!) { user(id: $uid) { id name } }",
+				ReadResultPath: "user",
+				IDVariable:     "uid",
+			},
+			response:   `{"data": {"user": {"id": "1", "name": "bar"}}}`,
+			wantID:     "1",
+			wantResult: map[string]any{"id": "1", "name": "bar"},
+		},
+		{
+			name:       "not found",
+			opts:       &MutationOptions{ReadQuery: "query($id: ID!) { user(id: $id) { id } }", ReadResultPath: "user"},
+			response:   `{"data": {"user": null}}`,
+			wantID:     "",
+			wantResult: nil,
+		},
+		{
+			name: "null parent",
+			opts: &MutationOptions{
+				ReadQuery:      "query($id: ID!) { repository(id: $id) { issue { id } } }",
+				ReadResultPath: "repository/issue",
+			},
+			response:   `{"data": {"repository": null}}`,
+			wantID:     "",
+			wantResult: nil,
+		},
+		{
+			name: "missing parent",
+			opts: &MutationOptions{
+				ReadQuery:      "query($id: ID!) { repository(id: $id) { issue { id } } }",
+				ReadResultPath: "repository/issue",
+			},
+			response: `{"data": {"project": null}}`,
+			wantErr:  ErrInvalidResponse,
+		},
+		{
+			name:       "no read query",
+			opts:       &MutationOptions{Result: "previous"},
+			wantID:     "1",
+			wantResult: "previous",
+		},
+		{
+			name:     "graphql errors",
+			opts:     &MutationOptions{ReadQuery: "query($id: ID!) { user(id: $id) { id } }"},
+			response: `{"errors": [{"message": "forbidden"}]}`,
+			wantErr:  ErrGraphQL,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []Request
+
+			tt.opts.ID = "1"
+			tt.opts.Variables = map[string]any{"name": "foo"}
+			m := newTestMutation(t, tt.opts)
+			testutils.RegisterRecordingResponder(t, http.MethodPost, testURL, &requests, tt.response)
+
+			err := m.Read(t.Context())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, ErrReadQuery)
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantID, m.Options.ID)
+			assert.Equal(t, tt.wantResult, m.Options.Result)
+			assert.Equal(t, map[string]any{"name": "foo"}, m.Options.Variables)
+
+			if tt.opts.ReadQuery == "" {
+				assert.Empty(t, requests)
+
+				return
+			}
+
+			assert.Equal(t, []Request{{
+				Query:     tt.opts.ReadQuery,
+				Variables: map[string]any{"name": "foo", m.Options.IDVariable: "1"},
+			}}, requests)
+		})
+	}
+}
+
+func TestMutationUpdate(t *testing.T) {
+	var requests []Request
+
+	m := newTestMutation(t, &MutationOptions{
+		ID:               "1",
+		UpdateMutation:   "mutation($id: ID!, $name: String!) { updateUser(id: $id, name: $name) { id name } }",
+		UpdateResultPath: "updateUser",
+		Variables:        map[string]any{"name": "bar"},
+	})
+	testutils.RegisterRecordingResponder(t, http.MethodPost, testURL, &requests,
+		`{"data": {"updateUser": {"id": "1", "name": "bar"}}}`)
+
+	assert.NoError(t, m.Update(t.Context()))
+	assert.Equal(t, map[string]any{"id": "1", "name": "bar"}, m.Options.Result)
+	assert.Equal(t, []Request{{
+		Query:     m.Options.UpdateMutation,
+		Variables: map[string]any{"id": "1", "name": "bar"},
+	}}, requests)
+
+	m.Options.UpdateMutation = ""
+
+	assert.NoError(t, m.Update(t.Context()))
+	assert.Len(t, requests, 1)
+
+	m.Options.ID = ""
+
+	assert.ErrorIs(t, m.Update(t.Context()), ErrUpdateMutation)
+}
+
+func TestMutationDelete(t *testing.T) {
+	var requests []Request
+
+	m := newTestMutation(t, &MutationOptions{
+		ID:             "1",
+		DeleteMutation: "mutation($id: ID!) { deleteUser(id: $id) }",
+	})
+	testutils.RegisterRecordingResponder(t, http.MethodPost, testURL, &requests,
+		`{"data": {"deleteUser": true}}`,
+		`{"data": {"deleteUser": false}, "errors": [{"message": "not allowed"}]}`,
+	)
+
+	assert.NoError(t, m.Delete(t.Context()))
+	assert.Equal(t, []Request{{Query: m.Options.DeleteMutation, Variables: map[string]any{"id": "1"}}}, requests)
+
+	err := m.Delete(t.Context())
+	assert.ErrorIs(t, err, ErrDeleteMutation)
+	assert.ErrorContains(t, err, "not allowed")
+
+	m.Options.DeleteMutation = ""
+
+	assert.NoError(t, m.Delete(t.Context()))
+	assert.Len(t, requests, 2)
+}
+
+func TestMutationTracing(t *testing.T) {
+	var requests []Request
+
+	client, collector := mockclient.NewTraced(t, &restclient.ClientOptions{})
+
+	m, err := NewMutation(client, &MutationOptions{
+		CreateMutation: "mutation { createUser { id } }",
+		IDPath:         "createUser/id",
+	})
+	assert.NoError(t, err)
+
+	testutils.RegisterRecordingResponder(t, http.MethodPost, testURL, &requests, `{"data": {"createUser": {"id": "1"}}}`)
+
+	assert.NoError(t, m.Create(t.Context()))
+
+	spans := collector.Spans()
+
+	testutils.AssertChildSpan(t, spans, "graphql.mutation.create", http.MethodPost)
+}
@@ -0,0 +1,73 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var (
+	ErrInvalidQueryOptions = errors.New("invalid query options")
+	ErrQuery               = errors.New("failed to query data")
+)
+
+// Query is the state holding struct for a restapi_graphql_query data source.
+type Query struct {
+	client  *restclient.RestClient
+	Options *QueryOptions
+}
+
+type QueryOptions struct {
+	Path       string
+	Query      string
+	Variables  map[string]any
+	ResultPath string
+
+	// Set internally
+	Result      any // Result as extracted from the response
+	ResponseRaw string
+}
+
+// NewQuery creates a new Query instance with the given client and options.
+// It sets default values for the options if they are not provided.
+func NewQuery(client *restclient.RestClient, opts *QueryOptions) (*Query, error) {
+	if opts.Path == "" {
+		opts.Path = DefaultPath
+	}
+
+	if opts.Query == "" {
+		return nil, fmt.Errorf("%w: query not set", ErrInvalidQueryOptions)
+	}
+
+	return &Query{client: client, Options: opts}, nil
+}
+
+// Read sends the query and extracts the result from the result path of the
+// response data.
+func (q *Query) Read(ctx context.Context) (err error) {
+	ctx, span := q.client.StartSpan(ctx, "graphql.query.read",
+		attribute.String("restapi.object.path", q.Options.Path),
+	)
+	defer func() { q.client.EndSpan(ctx, span, err) }()
+
+	opts := q.Options
+
+	resp, err := Execute(ctx, q.client, opts.Path, opts.Query, opts.Variables)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrQuery, err)
+	}
+
+	result, err := resultAt(resp.Data, opts.ResultPath)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrQuery, err)
+	}
+
+	opts.Result = result
+	opts.ResponseRaw = resp.Raw
+
+	return nil
+}
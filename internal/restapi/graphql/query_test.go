@@ -0,0 +1,74 @@
+package graphql
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils/mockclient"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryRead(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     *QueryOptions
+		response string
+		want     any
+		wantErr  error
+	}{
+		{
+			name:     "full data",
+			opts:     &QueryOptions{Query: "query { users { id } }"},
+			response: `{"data": {"users": [{"id": "1"}]}}`,
+			want:     map[string]any{"users": []any{map[string]any{"id": "1"}}},
+		},
+		{
+			name:     "result path",
+			opts:     &QueryOptions{Query: "query { users { id } }", ResultPath: "users/0"},
+			response: `{"data": {"users": [{"id": "1"}]}}`,
+			want:     map[string]any{"id": "1"},
+		},
+		{
+			name:     "invalid result path",
+			opts:     &QueryOptions{Query: "query { users { id } }", ResultPath: "groups"},
+			response: `{"data": {"users": []}}`,
+			wantErr:  ErrInvalidResponse,
+		},
+		{
+			name:     "graphql errors",
+			opts:     &QueryOptions{Query: "query { users { id } }"},
+			response: `{"data": null, "errors": [{"message": "forbidden"}]}`,
+			wantErr:  ErrGraphQL,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []Request
+
+			client := mockclient.New(t, &restclient.ClientOptions{})
+			testutils.RegisterRecordingResponder(t, http.MethodPost, testURL, &requests, tt.response)
+
+			q, err := NewQuery(client, tt.opts)
+			assert.NoError(t, err)
+
+			err = q.Read(t.Context())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, ErrQuery)
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, q.Options.Result)
+			assert.Equal(t, tt.response, q.Options.ResponseRaw)
+		})
+	}
+
+	_, err := NewQuery(nil, &QueryOptions{})
+	assert.ErrorIs(t, err, ErrInvalidQueryOptions)
+}
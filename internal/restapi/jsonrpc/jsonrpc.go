@@ -0,0 +1,94 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+)
+
+const (
+	// Version is the supported JSON-RPC protocol version.
+	Version = "2.0"
+	// requestID is the ID of all calls. Every call is sent with its own HTTP
+	// request, so responses do not need to be matched to their calls.
+	requestID = 1
+)
+
+var (
+	ErrJSONRPC         = errors.New("json-rpc call failed")
+	ErrInvalidResponse = errors.New("invalid json-rpc response")
+)
+
+// Request is the body of a JSON-RPC call.
+type Request struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int    `json:"id"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+// Response is the body of a JSON-RPC response.
+type Response struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      any    `json:"id"`
+	Result  any    `json:"result"`
+	Error   *Error `json:"error"`
+
+	// Raw is the unmodified response body.
+	Raw string `json:"-"`
+}
+
+// Error is the error object of a JSON-RPC response.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+func (e *Error) Error() string {
+	if e.Data == nil {
+		return fmt.Sprintf("code %d: %s", e.Code, e.Message)
+	}
+
+	return fmt.Sprintf("code %d: %s: %v", e.Code, e.Message, e.Data)
+}
+
+// Call sends a JSON-RPC call of the method with the given params to the path
+// using the REST client. Error objects of the response are returned as *Error.
+func Call(ctx context.Context, client *restclient.RestClient, path, method string, params any) (*Response, error) {
+	body, err := json.Marshal(Request{JSONRPC: Version, ID: requestID, Method: method, Params: params})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrJSONRPC, err)
+	}
+
+	respBody, _, err := client.SendRequest(ctx, http.MethodPost, path, string(body))
+
+	// Servers may respond with an error object and a non-2xx status code, which
+	// is more helpful than the status code.
+	result := &Response{Raw: respBody}
+	if decodeErr := json.Unmarshal([]byte(respBody), result); decodeErr != nil {
+		if err != nil {
+			return result, err
+		}
+
+		return result, fmt.Errorf("%w: %w", ErrInvalidResponse, decodeErr)
+	}
+
+	if result.Error != nil {
+		return result, fmt.Errorf("%w: method '%s': %w", ErrJSONRPC, method, result.Error)
+	}
+
+	if err != nil {
+		return result, err
+	}
+
+	if result.JSONRPC != Version {
+		return result, fmt.Errorf("%w: unsupported version '%s'", ErrInvalidResponse, result.JSONRPC)
+	}
+
+	return result, nil
+}
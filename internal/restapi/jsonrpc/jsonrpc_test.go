@@ -0,0 +1,108 @@
+package jsonrpc
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils/mockclient"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const testURL = "https://restapi.local/rpc"
+
+func TestCall(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		want      any
+		wantErr   error
+		wantError *Error
+	}{
+		{
+			name:   "result",
+			status: http.StatusOK,
+			body:   `{"jsonrpc": "2.0", "id": 1, "result": {"id": 7, "name": "foo"}}`,
+			want:   map[string]any{"id": float64(7), "name": "foo"},
+		},
+		{
+			name:   "null result",
+			status: http.StatusOK,
+			body:   `{"jsonrpc": "2.0", "id": 1, "result": null}`,
+			want:   nil,
+		},
+		{
+			name:      "error on success",
+			status:    http.StatusOK,
+			body:      `{"jsonrpc": "2.0", "id": 1, "error": {"code": -32601, "message": "Method not found"}}`,
+			wantErr:   ErrJSONRPC,
+			wantError: &Error{Code: -32601, Message: "Method not found"},
+		},
+		{
+			name:      "error on failure",
+			status:    http.StatusInternalServerError,
+			body:      `{"jsonrpc": "2.0", "id": 1, "error": {"code": -32000, "message": "failed", "data": "disk full"}}`,
+			wantErr:   ErrJSONRPC,
+			wantError: &Error{Code: -32000, Message: "failed", Data: "disk full"},
+		},
+		{
+			name:    "failure without body",
+			status:  http.StatusBadGateway,
+			body:    "bad gateway",
+			wantErr: restclient.ErrUnexpectedResponseCode,
+		},
+		{
+			name:    "invalid response",
+			status:  http.StatusOK,
+			body:    "<html></html>",
+			wantErr: ErrInvalidResponse,
+		},
+		{
+			name:    "invalid version",
+			status:  http.StatusOK,
+			body:    `{"id": 1, "result": true}`,
+			wantErr: ErrInvalidResponse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := mockclient.New(t, &restclient.ClientOptions{})
+
+			httpmock.RegisterResponder(http.MethodPost, testURL, func(req *http.Request) (*http.Response, error) {
+				b, _ := io.ReadAll(req.Body)
+				assert.JSONEq(t, `{"jsonrpc": "2.0", "id": 1, "method": "user.get", "params": {"id": "7"}}`, string(b))
+				assert.Equal(t, restclient.ContentTypeJSON, req.Header.Get("Content-Type"))
+
+				return httpmock.NewStringResponse(tt.status, tt.body), nil
+			})
+
+			got, err := Call(t.Context(), client, "/rpc", "user.get", map[string]any{"id": "7"})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				var rpcErr *Error
+				if tt.wantError != nil && assert.True(t, errors.As(err, &rpcErr)) {
+					assert.Equal(t, tt.wantError, rpcErr)
+				}
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.Result)
+			assert.Equal(t, tt.body, got.Raw)
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	assert.Equal(t, "code -32602: Invalid params", (&Error{Code: -32602, Message: "Invalid params"}).Error())
+	assert.Equal(t, "code -32602: Invalid params: map[name:required]",
+		(&Error{Code: -32602, Message: "Invalid params", Data: map[string]any{"name": "required"}}).Error())
+}
@@ -0,0 +1,258 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DefaultIDKey is the key of the object ID in create results if no ID path is set.
+const DefaultIDKey = "id"
+
+var (
+	ErrInvalidObjectOptions = errors.New("invalid object options")
+	ErrCreateObject         = errors.New("failed to create object")
+	ErrReadObject           = errors.New("failed to read object")
+	ErrUpdateObject         = errors.New("failed to update object")
+	ErrDeleteObject         = errors.New("failed to delete object")
+)
+
+// Object is the state holding struct for a restapi_jsonrpc_object resource.
+type Object struct {
+	client  *restclient.RestClient
+	Options *ObjectOptions
+}
+
+type ObjectOptions struct {
+	Path         string
+	CreateMethod string
+	CreateParams any
+	ReadMethod   string
+	ReadParams   any
+	UpdateMethod string
+	UpdateParams any
+	DeleteMethod string
+	DeleteParams any
+	IDPath       string
+	Data         map[string]any
+
+	// Set internally
+	ID          string
+	Result      any // Result of the last call
+	ResponseRaw string
+}
+
+// NewObject creates a new Object instance with the given client and options.
+// Params templates that are not provided default to the object data for
+// create calls, the object data with the `id` key for update calls and
+// an object with the `id` key for read and delete calls.
+func NewObject(client *restclient.RestClient, opts *ObjectOptions) (*Object, error) {
+	if opts.CreateMethod == "" {
+		return nil, fmt.Errorf("%w: create method not set", ErrInvalidObjectOptions)
+	}
+
+	if opts.CreateParams == nil {
+		opts.CreateParams = "{data}"
+	}
+
+	if opts.ReadParams == nil {
+		opts.ReadParams = map[string]any{DefaultIDKey: "{id}"}
+	}
+
+	if opts.DeleteParams == nil {
+		opts.DeleteParams = map[string]any{DefaultIDKey: "{id}"}
+	}
+
+	return &Object{client: client, Options: opts}, nil
+}
+
+// Create calls the create method and gathers the object ID from the result.
+func (o *Object) Create(ctx context.Context) (err error) {
+	ctx, span := o.startSpan(ctx, "create")
+	defer func() { o.endSpan(ctx, span, err) }()
+
+	opts := o.Options
+
+	resp, err := o.call(ctx, opts.CreateMethod, opts.CreateParams)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCreateObject, err)
+	}
+
+	id, err := resultID(resp.Result, opts.IDPath)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCreateObject, err)
+	}
+
+	opts.ID = id
+	o.setResult(resp)
+
+	return nil
+}
+
+// Read calls the read method if configured. The object ID is removed if the
+// result is null, as the object does not exist (anymore).
+func (o *Object) Read(ctx context.Context) (err error) {
+	ctx, span := o.startSpan(ctx, "read")
+	defer func() { o.endSpan(ctx, span, err) }()
+
+	opts := o.Options
+
+	if opts.ID == "" {
+		return fmt.Errorf("%w: id not set", ErrReadObject)
+	}
+
+	if opts.ReadMethod == "" {
+		tflog.Debug(ctx, "no read method set: keeping the result of the last call")
+
+		return nil
+	}
+
+	resp, err := o.call(ctx, opts.ReadMethod, opts.ReadParams)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrReadObject, err)
+	}
+
+	o.setResult(resp)
+
+	if opts.Result == nil {
+		tflog.Warn(ctx, fmt.Sprintf("object '%s' not found: removing from state", opts.ID))
+
+		opts.ID = ""
+	}
+
+	return nil
+}
+
+// Update calls the update method if configured.
+func (o *Object) Update(ctx context.Context) (err error) {
+	ctx, span := o.startSpan(ctx, "update")
+	defer func() { o.endSpan(ctx, span, err) }()
+
+	opts := o.Options
+
+	if opts.ID == "" {
+		return fmt.Errorf("%w: id not set", ErrUpdateObject)
+	}
+
+	if opts.UpdateMethod == "" {
+		tflog.Debug(ctx, "no update method set: skipping update")
+
+		return nil
+	}
+
+	var resp *Response
+
+	if opts.UpdateParams != nil {
+		resp, err = o.call(ctx, opts.UpdateMethod, opts.UpdateParams)
+	} else {
+		// The data is sent as is, as it is no template.
+		params := maps.Clone(opts.Data)
+		if params == nil {
+			params = make(map[string]any)
+		}
+
+		params[DefaultIDKey] = opts.ID
+		resp, err = Call(ctx, o.client, opts.Path, opts.UpdateMethod, params)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUpdateObject, err)
+	}
+
+	o.setResult(resp)
+
+	return nil
+}
+
+// Delete calls the delete method if configured.
+func (o *Object) Delete(ctx context.Context) (err error) {
+	ctx, span := o.startSpan(ctx, "delete")
+	defer func() { o.endSpan(ctx, span, err) }()
+
+	opts := o.Options
+
+	if opts.ID == "" {
+		tflog.Warn(ctx, "attempt to delete an object that has no id set: assuming this is ok")
+
+		return nil
+	}
+
+	if opts.DeleteMethod == "" {
+		tflog.Warn(ctx, fmt.Sprintf("no delete method set: object '%s' is only removed from state", opts.ID))
+
+		return nil
+	}
+
+	if _, err := o.call(ctx, opts.DeleteMethod, opts.DeleteParams); err != nil {
+		return fmt.Errorf("%w: %w", ErrDeleteObject, err)
+	}
+
+	return nil
+}
+
+// startSpan starts the span of an operation of the object, see
+// restclient.RestClient.StartSpan.
+func (o *Object) startSpan(ctx context.Context, operation string) (context.Context, trace.Span) {
+	return o.client.StartSpan(ctx, "jsonrpc.object."+operation,
+		attribute.String("restapi.object.path", o.Options.Path),
+	)
+}
+
+// endSpan ends the span of an operation of the object with the ID of the
+// object, which might have been set by the operation.
+func (o *Object) endSpan(ctx context.Context, span trace.Span, err error) {
+	span.SetAttributes(attribute.String("restapi.object.id", o.Options.ID))
+	o.client.EndSpan(ctx, span, err)
+}
+
+// call expands the params template and calls the method.
+func (o *Object) call(ctx context.Context, method string, template any) (*Response, error) {
+	params, err := expandParams(template, o.Options.ID, o.Options.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return Call(ctx, o.client, o.Options.Path, method, params)
+}
+
+func (o *Object) setResult(resp *Response) {
+	o.Options.Result = resp.Result
+	o.Options.ResponseRaw = resp.Raw
+}
+
+// resultID returns the object ID at the path in the result. If no path is
+// set, the result itself is used if it is a string or number, and its `id`
+// key otherwise.
+func resultID(result any, path string) (string, error) {
+	if path == "" {
+		switch v := result.(type) {
+		case string:
+			return v, nil
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		}
+
+		path = DefaultIDKey
+	}
+
+	obj, ok := result.(map[string]any)
+	if !ok {
+		return "", fmt.Errorf("%w: result not an object but '%T'", ErrInvalidResponse, result)
+	}
+
+	id, err := utils.GetStringAtKey(obj, path)
+	if err != nil {
+		return "", fmt.Errorf("%w: failed to find id at '%s': %w", ErrInvalidResponse, path, err)
+	}
+
+	return id, nil
+}
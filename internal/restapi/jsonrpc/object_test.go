@@ -0,0 +1,275 @@
+package jsonrpc
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils/mockclient"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestObject(t *testing.T, opts *ObjectOptions) *Object {
+	t.Helper()
+
+	opts.Path = "/rpc"
+
+	if opts.CreateMethod == "" {
+		opts.CreateMethod = "user.create"
+	}
+
+	if opts.Data == nil {
+		opts.Data = map[string]any{"name": "foo"}
+	}
+
+	o, err := NewObject(mockclient.New(t, &restclient.ClientOptions{}), opts)
+	assert.NoError(t, err)
+
+	return o
+}
+
+func TestNewObject(t *testing.T) {
+	_, err := NewObject(nil, &ObjectOptions{})
+	assert.ErrorIs(t, err, ErrInvalidObjectOptions)
+}
+
+func TestObjectCreate(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       *ObjectOptions
+		response   string
+		wantParams any
+		wantID     string
+		wantErr    error
+	}{
+		{
+			name:       "object result",
+			opts:       &ObjectOptions{},
+			response:   `{"jsonrpc": "2.0", "id": 1, "result": {"id": 7, "name": "foo"}}`,
+			wantParams: map[string]any{"name": "foo"},
+			wantID:     "7",
+		},
+		{
+			name:       "scalar result",
+			opts:       &ObjectOptions{CreateParams: []any{"{data.name}"}},
+			response:   `{"jsonrpc": "2.0", "id": 1, "result": "u-7"}`,
+			wantParams: []any{"foo"},
+			wantID:     "u-7",
+		},
+		{
+			name:       "large scalar result",
+			opts:       &ObjectOptions{},
+			response:   `{"jsonrpc": "2.0", "id": 1, "result": 1000000}`,
+			wantParams: map[string]any{"name": "foo"},
+			wantID:     "1000000",
+		},
+		{
+			name:       "large object result",
+			opts:       &ObjectOptions{},
+			response:   `{"jsonrpc": "2.0", "id": 1, "result": {"id": 123456789012}}`,
+			wantParams: map[string]any{"name": "foo"},
+			wantID:     "123456789012",
+		},
+		{
+			name:       "id path",
+			opts:       &ObjectOptions{IDPath: "user/uid"},
+			response:   `{"jsonrpc": "2.0", "id": 1, "result": {"user": {"uid": "u-7"}}}`,
+			wantParams: map[string]any{"name": "foo"},
+			wantID:     "u-7",
+		},
+		{
+			name:     "id not found",
+			opts:     &ObjectOptions{},
+			response: `{"jsonrpc": "2.0", "id": 1, "result": {"name": "foo"}}`,
+			wantErr:  ErrInvalidResponse,
+		},
+		{
+			name:     "error object",
+			opts:     &ObjectOptions{},
+			response: `{"jsonrpc": "2.0", "id": 1, "error": {"code": -32602, "message": "Invalid params"}}`,
+			wantErr:  ErrJSONRPC,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []Request
+
+			o := newTestObject(t, tt.opts)
+			testutils.RegisterRecordingResponder(t, http.MethodPost, testURL, &requests, tt.response)
+
+			err := o.Create(t.Context())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, ErrCreateObject)
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantID, o.Options.ID)
+			assert.Equal(t, tt.response, o.Options.ResponseRaw)
+			assert.Equal(t, []Request{{JSONRPC: Version, ID: requestID, Method: "user.create", Params: tt.wantParams}}, requests)
+		})
+	}
+}
+
+func TestObjectRead(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       *ObjectOptions
+		response   string
+		wantParams any
+		wantID     string
+		wantResult any
+		wantErr    error
+	}{
+		{
+			name:       "read",
+			opts:       &ObjectOptions{ReadMethod: "user.get"},
+			response:   `{"jsonrpc": "2.0", "id": 1, "result": {"id": "7", "name": "bar"}}`,
+			wantParams: map[string]any{"id": "7"},
+			wantID:     "7",
+			wantResult: map[string]any{"id": "7", "name": "bar"},
+		},
+		{
+			name:       "params template",
+			opts:       &ObjectOptions{ReadMethod: "user.get", ReadParams: []any{"{id}", []any{"name"}}},
+			response:   `{"jsonrpc": "2.0", "id": 1, "result": {"name": "bar"}}`,
+			wantParams: []any{"7", []any{"name"}},
+			wantID:     "7",
+			wantResult: map[string]any{"name": "bar"},
+		},
+		{
+			name:       "not found",
+			opts:       &ObjectOptions{ReadMethod: "user.get"},
+			response:   `{"jsonrpc": "2.0", "id": 1, "result": null}`,
+			wantParams: map[string]any{"id": "7"},
+			wantID:     "",
+		},
+		{
+			name:       "no read method",
+			opts:       &ObjectOptions{Result: "previous"},
+			wantID:     "7",
+			wantResult: "previous",
+		},
+		{
+			name:     "error object",
+			opts:     &ObjectOptions{ReadMethod: "user.get"},
+			response: `{"jsonrpc": "2.0", "id": 1, "error": {"code": -32001, "message": "access denied"}}`,
+			wantErr:  ErrJSONRPC,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []Request
+
+			tt.opts.ID = "7"
+			o := newTestObject(t, tt.opts)
+			testutils.RegisterRecordingResponder(t, http.MethodPost, testURL, &requests, tt.response)
+
+			err := o.Read(t.Context())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, ErrReadObject)
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantID, o.Options.ID)
+			assert.Equal(t, tt.wantResult, o.Options.Result)
+
+			if tt.opts.ReadMethod == "" {
+				assert.Empty(t, requests)
+
+				return
+			}
+
+			assert.Equal(t, []Request{{JSONRPC: Version, ID: requestID, Method: "user.get", Params: tt.wantParams}}, requests)
+		})
+	}
+}
+
+func TestObjectUpdate(t *testing.T) {
+	var requests []Request
+
+	o := newTestObject(t, &ObjectOptions{
+		ID:           "7",
+		UpdateMethod: "user.update",
+		Data:         map[string]any{"name": "bar", "note": "{id}"},
+	})
+	testutils.RegisterRecordingResponder(t, http.MethodPost, testURL, &requests,
+		`{"jsonrpc": "2.0", "id": 1, "result": {"id": "7", "name": "bar"}}`,
+		`{"jsonrpc": "2.0", "id": 1, "result": true}`,
+	)
+
+	assert.NoError(t, o.Update(t.Context()))
+	assert.Equal(t, map[string]any{"id": "7", "name": "bar"}, o.Options.Result)
+
+	o.Options.UpdateParams = []any{"{id}", map[string]any{"name": "{data.name}"}}
+
+	assert.NoError(t, o.Update(t.Context()))
+	assert.Equal(t, true, o.Options.Result)
+	assert.Equal(t, []Request{
+		{JSONRPC: Version, ID: requestID, Method: "user.update", Params: map[string]any{
+			"id": "7", "name": "bar", "note": "{id}",
+		}},
+		{JSONRPC: Version, ID: requestID, Method: "user.update", Params: []any{"7", map[string]any{"name": "bar"}}},
+	}, requests)
+	assert.Equal(t, map[string]any{"name": "bar", "note": "{id}"}, o.Options.Data)
+
+	o.Options.UpdateMethod = ""
+
+	assert.NoError(t, o.Update(t.Context()))
+	assert.Len(t, requests, 2)
+
+	o.Options.ID = ""
+
+	assert.ErrorIs(t, o.Update(t.Context()), ErrUpdateObject)
+}
+
+func TestObjectDelete(t *testing.T) {
+	var requests []Request
+
+	o := newTestObject(t, &ObjectOptions{ID: "7", DeleteMethod: "user.delete"})
+	testutils.RegisterRecordingResponder(t, http.MethodPost, testURL, &requests,
+		`{"jsonrpc": "2.0", "id": 1, "result": null}`,
+		`{"jsonrpc": "2.0", "id": 1, "error": {"code": -32000, "message": "in use"}}`,
+	)
+
+	assert.NoError(t, o.Delete(t.Context()))
+	assert.Equal(t, []Request{
+		{JSONRPC: Version, ID: requestID, Method: "user.delete", Params: map[string]any{"id": "7"}},
+	}, requests)
+
+	err := o.Delete(t.Context())
+	assert.ErrorIs(t, err, ErrDeleteObject)
+	assert.ErrorContains(t, err, "code -32000: in use")
+
+	o.Options.DeleteMethod = ""
+
+	assert.NoError(t, o.Delete(t.Context()))
+	assert.Len(t, requests, 2)
+}
+
+func TestObjectTracing(t *testing.T) {
+	var requests []Request
+
+	client, collector := mockclient.NewTraced(t, &restclient.ClientOptions{})
+
+	o, err := NewObject(client, &ObjectOptions{Path: "/rpc", CreateMethod: "user.create"})
+	assert.NoError(t, err)
+
+	testutils.RegisterRecordingResponder(t, http.MethodPost, testURL, &requests,
+		`{"jsonrpc": "2.0", "id": 1, "result": {"id": 7}}`)
+
+	assert.NoError(t, o.Create(t.Context()))
+
+	spans := collector.Spans()
+
+	testutils.AssertChildSpan(t, spans, "jsonrpc.object.create", http.MethodPost)
+}
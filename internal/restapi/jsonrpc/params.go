@@ -0,0 +1,86 @@
+package jsonrpc
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+)
+
+var ErrParamsTemplate = errors.New("failed to expand params template")
+
+// expandParams replaces the placeholders in all strings of the params template.
+// A string that consists of a single placeholder is replaced by the value
+// itself, which keeps numbers, booleans and objects intact. Placeholders
+// within longer strings are replaced by the formatted value. Supported
+// placeholders are `{id}`, `{data}` and `{data.<key>}`.
+func expandParams(template any, id string, data map[string]any) (any, error) {
+	switch v := template.(type) {
+	case string:
+		return expandParamsString(v, id, data)
+	case map[string]any:
+		result := make(map[string]any, len(v))
+
+		for key, value := range v {
+			expanded, err := expandParams(value, id, data)
+			if err != nil {
+				return nil, err
+			}
+
+			result[key] = expanded
+		}
+
+		return result, nil
+	case []any:
+		result := make([]any, 0, len(v))
+
+		for _, value := range v {
+			expanded, err := expandParams(value, id, data)
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, expanded)
+		}
+
+		return result, nil
+	}
+
+	return template, nil
+}
+
+func expandParamsString(template, id string, data map[string]any) (any, error) {
+	scopes := []string{"id", "data"}
+
+	if scope, key, ok := utils.ParsePlaceholder(template); ok && slices.Contains(scopes, scope) {
+		return resolveParam(scope, key, id, data)
+	}
+
+	return utils.ExpandPlaceholders(template, scopes, func(scope, key string) (string, error) {
+		value, err := resolveParam(scope, key, id, data)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("%v", value), nil
+	})
+}
+
+func resolveParam(name, key, id string, data map[string]any) (any, error) {
+	switch {
+	case name == "id" && key == "":
+		return id, nil
+	case name == "data" && key == "":
+		return data, nil
+	case name == "data":
+		value, err := utils.GetObjectAtKey(data, key)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrParamsTemplate, err)
+		}
+
+		return value, nil
+	}
+
+	return nil, fmt.Errorf("%w: unknown placeholder '{%s.%s}'", ErrParamsTemplate, name, key)
+}
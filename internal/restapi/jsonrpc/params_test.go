@@ -0,0 +1,78 @@
+package jsonrpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandParams(t *testing.T) {
+	data := map[string]any{
+		"name":    "foo",
+		"size":    float64(2),
+		"enabled": true,
+		"owner":   map[string]any{"id": "3"},
+	}
+
+	tests := []struct {
+		name     string
+		template any
+		want     any
+		wantErr  error
+	}{
+		{
+			name:     "data",
+			template: "{data}",
+			want:     data,
+		},
+		{
+			name:     "named params",
+			template: map[string]any{"id": "{id}", "size": "{data.size}", "owner": "{data.owner/id}", "force": true},
+			want:     map[string]any{"id": "42", "size": float64(2), "owner": "3", "force": true},
+		},
+		{
+			name:     "positional params",
+			template: []any{"{id}", "{data}", float64(1)},
+			want:     []any{"42", data, float64(1)},
+		},
+		{
+			name:     "embedded placeholders",
+			template: map[string]any{"label": "{data.name}-{id} ({data.enabled})"},
+			want:     map[string]any{"label": "foo-42 (true)"},
+		},
+		{
+			name:     "no placeholders",
+			template: map[string]any{"filter": "{name}"},
+			want:     map[string]any{"filter": "{name}"},
+		},
+		{
+			name:     "missing key",
+			template: map[string]any{"name": "{data.missing}"},
+			wantErr:  ErrParamsTemplate,
+		},
+		{
+			name:     "missing embedded key",
+			template: []any{"name-{data.missing}"},
+			wantErr:  ErrParamsTemplate,
+		},
+		{
+			name:     "unknown placeholder",
+			template: "{id.name}",
+			wantErr:  ErrParamsTemplate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandParams(tt.template, "42", data)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
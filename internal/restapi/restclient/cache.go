@@ -0,0 +1,124 @@
+package restclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/sync/singleflight"
+)
+
+type cacheEntry struct {
+	path     string
+	response *Response
+	expires  time.Time
+}
+
+// responseCache is an in-memory cache for responses of read requests.
+// Concurrent identical requests are deduplicated, and all entries related
+// to a path are invalidated by write requests to that path.
+type responseCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu         sync.Mutex
+	entries    map[string]cacheEntry
+	generation uint64
+	group      singleflight.Group
+}
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+// get returns the cached response for the given method and URL. If there is no
+// valid entry, fetch is called once for all concurrent callers and successful
+// responses are stored in the cache. The shared fetch is not cancelled with the
+// context of the first caller, as the other callers are waiting for its response.
+func (c *responseCache) get(
+	ctx context.Context, method, rawURL string, fetch func(ctx context.Context) (*Response, error),
+) (*Response, error) {
+	key := cacheKey(method, rawURL)
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	generation := c.generation
+	c.mu.Unlock()
+
+	if ok && c.now().Before(entry.expires) {
+		tflog.Debug(ctx, fmt.Sprintf("use cached response for '%s'", key))
+
+		return entry.response, nil
+	}
+
+	res, err, shared := c.group.Do(key, func() (any, error) {
+		response, err := fetch(context.WithoutCancel(ctx))
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		// Do not store the response if the cache was invalidated during the request,
+		// as the response might already be outdated.
+		if err == nil && generation == c.generation {
+			c.entries[key] = cacheEntry{
+				path:     cachePath(rawURL),
+				response: response,
+				expires:  c.now().Add(c.ttl),
+			}
+		}
+
+		return response, err
+	})
+
+	if shared {
+		tflog.Debug(ctx, fmt.Sprintf("use shared response for '%s'", key))
+	}
+
+	//nolint:forcetypeassert
+	return res.(*Response), err
+}
+
+// invalidate removes all entries whose path equals the given path or
+// is a parent or child of it.
+func (c *responseCache) invalidate(ctx context.Context, rawURL string) {
+	path := cachePath(rawURL)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+
+	for key, entry := range c.entries {
+		if entry.path == path ||
+			strings.HasPrefix(entry.path, path+"/") ||
+			strings.HasPrefix(path, entry.path+"/") {
+			tflog.Debug(ctx, fmt.Sprintf("invalidate cached response for '%s'", key))
+
+			delete(c.entries, key)
+			c.group.Forget(key)
+		}
+	}
+}
+
+func cacheKey(method, rawURL string) string {
+	return fmt.Sprintf("%s %s", method, rawURL)
+}
+
+// cachePath returns the URL path without query string and trailing slash.
+func cachePath(rawURL string) string {
+	path := rawURL
+
+	if u, err := url.Parse(rawURL); err == nil {
+		path = u.EscapedPath()
+	}
+
+	return strings.TrimSuffix(path, "/")
+}
@@ -0,0 +1,166 @@
+package restclient
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIClientCache(t *testing.T) {
+	tests := []struct {
+		name      string
+		requests  []string
+		wantCalls int
+	}{
+		{
+			name:      "cached read",
+			requests:  []string{"GET /things", "GET /things", "GET /things"},
+			wantCalls: 1,
+		},
+		{
+			name:      "distinct query",
+			requests:  []string{"GET /things", "GET /things?name=foo", "GET /things?name=foo"},
+			wantCalls: 2,
+		},
+		{
+			name:      "invalidate by child write",
+			requests:  []string{"GET /things", "PUT /things/1", "GET /things"},
+			wantCalls: 3,
+		},
+		{
+			name:      "invalidate by parent write",
+			requests:  []string{"GET /things/1", "POST /things", "GET /things/1"},
+			wantCalls: 3,
+		},
+		{
+			name:      "unrelated write",
+			requests:  []string{"GET /things", "DELETE /other/1", "GET /things"},
+			wantCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newMockClient(t, &ClientOptions{CacheTTL: 60, RateLimit: 100})
+
+			httpmock.RegisterNoResponder(httpmock.NewStringResponder(http.StatusOK, "OK"))
+
+			for _, request := range tt.requests {
+				method, path, _ := strings.Cut(request, " ")
+
+				_, _, err := client.SendRequest(t.Context(), method, path, "")
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wantCalls, httpmock.GetTotalCallCount())
+		})
+	}
+}
+
+func TestAPIClientCacheExpiry(t *testing.T) {
+	client := newMockClient(t, &ClientOptions{CacheTTL: 1, RateLimit: 100})
+
+	now := time.Now()
+	client.cache.now = func() time.Time { return now }
+
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://restapi.local/things",
+		httpmock.NewStringResponder(http.StatusOK, "OK"),
+	)
+
+	for range 2 {
+		_, _, err := client.SendRequest(t.Context(), http.MethodGet, "/things", "")
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+
+	now = now.Add(time.Second + time.Millisecond)
+
+	_, _, err := client.SendRequest(t.Context(), http.MethodGet, "/things", "")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, httpmock.GetTotalCallCount())
+}
+
+func TestAPIClientCacheConcurrent(t *testing.T) {
+	client := newMockClient(t, &ClientOptions{CacheTTL: 60, RateLimit: 100})
+
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://restapi.local/things",
+		httpmock.NewStringResponder(http.StatusOK, "OK").Delay(500*time.Millisecond),
+	)
+
+	var wg sync.WaitGroup
+
+	for range 10 {
+		wg.Go(func() {
+			res, _, err := client.SendRequest(t.Context(), http.MethodGet, "/things", "")
+			assert.NoError(t, err)
+			assert.Equal(t, "OK", res)
+		})
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+func TestAPIClientCacheCanceled(t *testing.T) {
+	client := newMockClient(t, &ClientOptions{CacheTTL: 60, RateLimit: 100})
+
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://restapi.local/things",
+		httpmock.NewStringResponder(http.StatusOK, "OK").Delay(200*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	var wg sync.WaitGroup
+
+	wg.Go(func() {
+		_, _, _ = client.SendRequest(ctx, http.MethodGet, "/things", "")
+	})
+
+	// Wait for the first request to be in flight before joining it.
+	time.Sleep(50 * time.Millisecond)
+
+	wg.Go(func() {
+		res, _, err := client.SendRequest(t.Context(), http.MethodGet, "/things", "")
+		assert.NoError(t, err)
+		assert.Equal(t, "OK", res)
+	})
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	wg.Wait()
+
+	assert.Equal(t, 1, httpmock.GetTotalCallCount())
+}
+
+func TestAPIClientCacheError(t *testing.T) {
+	client := newMockClient(t, &ClientOptions{CacheTTL: 60, RateLimit: 100})
+
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://restapi.local/things",
+		httpmock.NewStringResponder(http.StatusInternalServerError, "FAIL"),
+	)
+
+	for range 2 {
+		_, status, err := client.SendRequest(t.Context(), http.MethodGet, "/things", "")
+		assert.ErrorIs(t, err, ErrUnexpectedResponseCode)
+		assert.Equal(t, http.StatusInternalServerError, status)
+	}
+
+	assert.Equal(t, 2, httpmock.GetTotalCallCount())
+}
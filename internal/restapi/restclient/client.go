@@ -10,11 +10,18 @@ import (
 	"math"
 	"net/http"
 	"net/http/cookiejar"
+	"net/http/httptrace"
 	"net/url"
 	"strings"
 	"time"
 
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
 	"golang.org/x/time/rate"
@@ -32,8 +39,13 @@ type ClientOptions struct {
 	Username               string
 	Password               string
 	Headers                map[string]string
+	SensitiveHeaders       []string
 	UseCookies             bool
 	Timeout                int64
+	CacheTTL               int64
+	RequestFormat          string
+	ResponseFormat         string
+	XMLRoot                string
 	IDAttribute            string
 	CreateMethod           string
 	ReadMethod             string
@@ -45,6 +57,9 @@ type ClientOptions struct {
 	WriteReturnsObject     bool
 	CreateReturnsObject    bool
 	XSSIPrefix             string
+	RequestEnvelope        string
+	ResponseKey            string
+	JSONAPI                bool
 	RateLimit              float64
 	TestPath               string
 	OAuthClientCredentials *OAuthCredentials
@@ -52,6 +67,10 @@ type ClientOptions struct {
 	KeyString              string
 	CertFile               string
 	KeyFile                string
+	Tracing                *TracingOptions
+	RecordHARFile          string
+	// Version is the provider version, e.g. recorded as creator of HAR files.
+	Version string
 }
 
 type OAuthCredentials struct {
@@ -71,9 +90,15 @@ type ResponseFilter struct {
 type RestClient struct {
 	HTTPClient *http.Client
 	Options    *ClientOptions
-
-	rateLimiter *rate.Limiter
-	oauthConfig *clientcredentials.Config
+	Codecs     *Codecs
+
+	rateLimiter    *rate.Limiter
+	oauthConfig    *clientcredentials.Config
+	cache          *responseCache
+	tracer         trace.Tracer
+	tracerProvider *sdktrace.TracerProvider
+	flushTimeout   time.Duration
+	har            *harRecorder
 }
 
 // New creates a new RestClient instance.
@@ -111,6 +136,27 @@ func New(ctx context.Context, opts *ClientOptions) (*RestClient, error) {
 		opts.DestroyMethod = "DELETE"
 	}
 
+	if opts.RequestFormat == "" {
+		opts.RequestFormat = FormatJSON
+	}
+
+	if opts.XMLRoot == "" {
+		opts.XMLRoot = DefaultXMLRoot
+	}
+
+	codecs := NewCodecs()
+	codecs.Register(FormatXML, &XMLCodec{Root: opts.XMLRoot})
+
+	if err := checkFormat(opts.RequestFormat, RequestFormats()); err != nil {
+		return nil, fmt.Errorf("%w: request_format: %w", ErrInvalidClientOptions, err)
+	}
+
+	if opts.ResponseFormat != "" {
+		if err := checkFormat(opts.ResponseFormat, ResponseFormats()); err != nil {
+			return nil, fmt.Errorf("%w: response_format: %w", ErrInvalidClientOptions, err)
+		}
+	}
+
 	if opts.OAuthClientCredentials == nil {
 		opts.OAuthClientCredentials = &OAuthCredentials{}
 	}
@@ -166,10 +212,28 @@ func New(ctx context.Context, opts *ClientOptions) (*RestClient, error) {
 			Jar:       cookieJar,
 		},
 		Options: opts,
+		Codecs:  codecs,
 
 		rateLimiter: rateLimiter,
 	}
 
+	if opts.CacheTTL > 0 {
+		rc.cache = newResponseCache(time.Second * time.Duration(opts.CacheTTL))
+	}
+
+	tracer, tracerProvider, err := newTracer(ctx, opts.Tracing)
+	if err != nil {
+		return nil, err
+	}
+
+	rc.tracer = tracer
+	rc.tracerProvider = tracerProvider
+	rc.flushTimeout = tracingFlushTimeout
+
+	if opts.RecordHARFile != "" {
+		rc.har = newHARRecorder(opts.RecordHARFile, opts.Version)
+	}
+
 	if opts.OAuthClientCredentials.ClientID != "" &&
 		opts.OAuthClientCredentials.ClientSecret != "" &&
 		opts.OAuthClientCredentials.TokenEndpoint != "" {
@@ -187,34 +251,117 @@ func New(ctx context.Context, opts *ClientOptions) (*RestClient, error) {
 	return &rc, nil
 }
 
-// SendRequest sends an HTTP request to the configured API endpoint.
-// It handles constructing the request, adding headers and authentication,
-// rate limiting, logging, and error handling.
+// Request holds a request to send to the API.
+type Request struct {
+	Method      string
+	Path        string
+	Body        string
+	ContentType string
+	// Header holds additional request headers, which take precedence
+	// over the headers defined in the client options.
+	Header http.Header
+	// SensitiveKeys holds slash-delimited key paths of the request and
+	// response bodies, which are redacted from logs and errors.
+	SensitiveKeys []string
+}
+
+// Response holds the result of a request sent to the API.
+type Response struct {
+	Body       string
+	StatusCode int
+	Header     http.Header
+}
+
+// SendRequest sends an HTTP request with JSON data to the configured API endpoint
+// and returns the response body and status code. See Do for details.
 func (rc *RestClient) SendRequest(ctx context.Context, method, path, data string) (string, int, error) {
-	var (
-		req *http.Request
-		err error
-	)
+	return rc.SendRequestWithContentType(ctx, method, path, data, ContentTypeJSON)
+}
+
+// SendRequestWithContentType sends an HTTP request like SendRequest, but with the
+// given content type of the request data, e.g. as returned by Codec.Encode.
+func (rc *RestClient) SendRequestWithContentType(
+	ctx context.Context, method, path, data, contentType string,
+) (string, int, error) {
+	resp, err := rc.Do(ctx, method, path, data, contentType)
 
+	return resp.Body, resp.StatusCode, err
+}
+
+// Do sends an HTTP request to the configured API endpoint and returns the response.
+// It handles constructing the request, adding headers and authentication,
+// rate limiting, logging, and error handling. If the response cache is enabled,
+// GET requests are served from the cache and all other requests invalidate
+// the cached responses of the requested path. The returned response is never nil,
+// and also holds the response of failed requests with unexpected status codes.
+func (rc *RestClient) Do(ctx context.Context, method, path, data, contentType string) (*Response, error) {
+	return rc.DoRequest(ctx, &Request{Method: method, Path: path, Body: data, ContentType: contentType})
+}
+
+// DoRequest sends the request to the configured API endpoint like Do. Requests
+// with additional headers are never served from the response cache, as the
+// headers might change the response.
+func (rc *RestClient) DoRequest(ctx context.Context, r *Request) (*Response, error) {
 	opts := rc.Options
-	url := fmt.Sprintf("%s/%s", strings.TrimRight(opts.Endpoint, "/"), strings.TrimLeft(path, "/"))
+	url := fmt.Sprintf("%s/%s", strings.TrimRight(opts.Endpoint, "/"), strings.TrimLeft(r.Path, "/"))
+
+	tflog.Debug(ctx, fmt.Sprintf("method='%s', path='%s', full url (derived)='%s', data='%s'",
+		r.Method, r.Path, url, utils.RedactJSONString(r.Body, r.SensitiveKeys)))
+
+	if rc.cache == nil {
+		return rc.sendRequest(ctx, r, url)
+	}
+
+	if r.Method == http.MethodGet && r.Body == "" && len(r.Header) == 0 {
+		return rc.cache.get(ctx, r.Method, url, func(ctx context.Context) (*Response, error) {
+			return rc.sendRequest(ctx, r, url)
+		})
+	}
+
+	defer rc.cache.invalidate(ctx, url)
+
+	return rc.sendRequest(ctx, r, url)
+}
+
+// sendRequest sends the request to the API within a span of the HTTP request.
+func (rc *RestClient) sendRequest(ctx context.Context, r *Request, url string) (*Response, error) {
+	ctx, span := rc.tracer.Start(ctx, r.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(r.Method),
+			semconv.URLPath(r.Path),
+		),
+	)
+
+	resp, err := rc.send(ctx, r, url)
+	if resp.StatusCode != 0 {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	}
 
-	tflog.Debug(ctx, fmt.Sprintf("method='%s', path='%s', full url (derived)='%s', data='%s'", method, path, url, data))
+	rc.EndSpan(ctx, span, err)
+
+	return resp, err
+}
 
-	if data == "" {
-		req, err = http.NewRequestWithContext(ctx, method, url, nil)
+func (rc *RestClient) send(ctx context.Context, r *Request, url string) (result *Response, err error) {
+	var req *http.Request
+
+	opts := rc.Options
+
+	if r.Body == "" {
+		req, err = http.NewRequestWithContext(ctx, r.Method, url, nil)
 	} else {
-		buffer := bytes.NewBuffer([]byte(data))
-		req, err = http.NewRequestWithContext(ctx, method, url, buffer)
+		buffer := bytes.NewBuffer([]byte(r.Body))
+		req, err = http.NewRequestWithContext(ctx, r.Method, url, buffer)
 
-		// Default of application/json, but allow headers array to overwrite later
-		if err == nil {
-			req.Header.Set("Content-Type", "application/json")
+		// Default to the content type of the data, but allow headers array to overwrite later
+		if err == nil && r.ContentType != "" {
+			req.Header.Set("Content-Type", r.ContentType)
 		}
 	}
 
 	if err != nil {
-		return "", 0, err
+		return &Response{}, err
 	}
 
 	tflog.Debug(ctx, fmt.Sprintf("send http request to %s", req.URL))
@@ -224,13 +371,20 @@ func (rc *RestClient) SendRequest(ctx context.Context, method, path, data string
 		req.Header.Set(n, v)
 	}
 
+	for n, v := range r.Header {
+		req.Header[http.CanonicalHeaderKey(n)] = v
+	}
+
 	if rc.oauthConfig != nil {
-		ctxx := context.WithValue(ctx, oauth2.HTTPClient, rc.HTTPClient)
+		tokenCtx, span := rc.StartSpan(ctx, "oauth2.token")
+		ctxx := context.WithValue(tokenCtx, oauth2.HTTPClient, rc.HTTPClient)
 		tokenSource := rc.oauthConfig.TokenSource(ctxx)
 
 		token, err := tokenSource.Token()
+		rc.EndSpan(tokenCtx, span, err)
+
 		if err != nil {
-			return "", 0, err
+			return &Response{}, err
 		}
 
 		req.Header.Set("Authorization", "Bearer "+token.AccessToken)
@@ -241,14 +395,36 @@ func (rc *RestClient) SendRequest(ctx context.Context, method, path, data string
 		req.SetBasicAuth(opts.Username, opts.Password)
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("request headers: %v", req.Header))
-	tflog.Debug(ctx, fmt.Sprintf("request body: %v", data))
+	// Propagate the trace context to the API.
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	// Mask the credentials of all subsequent logs, and in particular the
+	// sensitive header values like the basic authentication data.
+	ctx = rc.maskSecrets(ctx, req.Header)
+
+	tflog.Debug(ctx, fmt.Sprintf("request headers: %v", rc.RedactHeaders(req.Header)))
+	tflog.Debug(ctx, fmt.Sprintf("request body: %v", utils.RedactJSONString(r.Body, r.SensitiveKeys)))
+
+	timer := newHARTimer()
+
+	if rc.har != nil {
+		req = req.WithContext(httptrace.WithClientTrace(req.Context(), timer.clientTrace()))
+
+		defer func() { rc.recordHAR(ctx, req, r, timer, result, err) }()
+	}
 
 	if rc.rateLimiter != nil {
 		// Rate limiting
 		tflog.Debug(ctx, "wait for rate limit availability")
 
-		if err := rc.rateLimiter.Wait(ctx); err != nil {
+		waitCtx, span := rc.StartSpan(ctx, "rate_limit.wait")
+
+		err := rc.rateLimiter.Wait(waitCtx)
+		rc.EndSpan(waitCtx, span, err)
+
+		timer.blocked = time.Since(timer.started)
+
+		if err != nil {
 			tflog.Warn(ctx, fmt.Sprintf("rate limiter failed: %s", err.Error()))
 		}
 	}
@@ -256,26 +432,31 @@ func (rc *RestClient) SendRequest(ctx context.Context, method, path, data string
 	//#nosec G704 // User must configure trusted endpoints
 	resp, err := rc.HTTPClient.Do(req)
 	if err != nil {
-		return "", 0, fmt.Errorf("%w: %s", ErrHTTPRequest, err.Error())
+		return &Response{}, fmt.Errorf("%w: %s", ErrHTTPRequest, err.Error())
 	}
 	defer resp.Body.Close()
 
+	timer.proto = resp.Proto
+
 	tflog.Debug(ctx, fmt.Sprintf("response code: %d", resp.StatusCode))
-	tflog.Debug(ctx, fmt.Sprintf("response header: %v", resp.Header))
+	tflog.Debug(ctx, fmt.Sprintf("response header: %v", rc.RedactHeaders(resp.Header)))
+
+	result = &Response{StatusCode: resp.StatusCode, Header: resp.Header}
 
 	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", resp.StatusCode, err
+		return result, err
 	}
 
-	body := strings.TrimPrefix(string(bodyBytes), opts.XSSIPrefix)
-	tflog.Debug(ctx, fmt.Sprintf("response body: %s", body))
+	result.Body = strings.TrimPrefix(string(bodyBytes), opts.XSSIPrefix)
+	redactedBody := utils.RedactJSONString(result.Body, r.SensitiveKeys)
+	tflog.Debug(ctx, fmt.Sprintf("response body: %s", redactedBody))
 
 	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
-		return body, resp.StatusCode, fmt.Errorf("%w: http %d: %s", ErrUnexpectedResponseCode, resp.StatusCode, body)
+		return result, fmt.Errorf("%w: http %d: %s", ErrUnexpectedResponseCode, resp.StatusCode, redactedBody)
 	}
 
-	return body, resp.StatusCode, nil
+	return result, nil
 }
 
 // ToString returns a string representation of the RestClient options.
@@ -287,13 +468,39 @@ func (rc *RestClient) ToString() string {
 	fmt.Fprintf(&buffer, "uri: %s\n", opts.Endpoint)
 	fmt.Fprintf(&buffer, "insecure: %t\n", opts.Insecure)
 	fmt.Fprintf(&buffer, "username: %s\n", opts.Username)
-	fmt.Fprintf(&buffer, "password: %s\n", opts.Password)
+	fmt.Fprintf(&buffer, "password: %s\n", redactString(opts.Password))
+
+	if oauth := opts.OAuthClientCredentials; oauth != nil {
+		fmt.Fprintf(&buffer, "oauth_client_id: %s\n", oauth.ClientID)
+		fmt.Fprintf(&buffer, "oauth_client_secret: %s\n", redactString(oauth.ClientSecret))
+		fmt.Fprintf(&buffer, "oauth_token_endpoint: %s\n", oauth.TokenEndpoint)
+	}
+
+	fmt.Fprintf(&buffer, "cache_ttl: %d\n", opts.CacheTTL)
+	fmt.Fprintf(&buffer, "request_format: %s\n", opts.RequestFormat)
+	fmt.Fprintf(&buffer, "response_format: %s\n", opts.ResponseFormat)
+	fmt.Fprintf(&buffer, "xml_root: %s\n", opts.XMLRoot)
 	fmt.Fprintf(&buffer, "id_attribute: %s\n", opts.IDAttribute)
 	fmt.Fprintf(&buffer, "write_returns_object: %t\n", opts.WriteReturnsObject)
 	fmt.Fprintf(&buffer, "create_returns_object: %t\n", opts.CreateReturnsObject)
+	fmt.Fprintf(&buffer, "request_envelope: %s\n", opts.RequestEnvelope)
+	fmt.Fprintf(&buffer, "response_key: %s\n", opts.ResponseKey)
+	fmt.Fprintf(&buffer, "jsonapi: %t\n", opts.JSONAPI)
+	fmt.Fprintf(&buffer, "sensitive_headers: %v\n", opts.SensitiveHeaders)
+
+	if opts.Tracing != nil {
+		fmt.Fprintf(&buffer, "tracing_endpoint: %s\n", opts.Tracing.Endpoint)
+	}
+
+	fmt.Fprintf(&buffer, "record_har_file: %s\n", opts.RecordHARFile)
+
 	buffer.WriteString("headers:\n")
 
 	for k, v := range opts.Headers {
+		if rc.isSensitiveHeader(k) {
+			v = redactString(v)
+		}
+
 		fmt.Fprintf(&buffer, " %s: %s\n", k, v)
 	}
 
@@ -97,6 +97,63 @@ func TestAPIClientRateLimit(t *testing.T) {
 	})
 }
 
+func TestAPIClientRequestHeader(t *testing.T) {
+	client := newMockClient(t, &ClientOptions{
+		RateLimit: 100,
+		CacheTTL:  60,
+		Headers:   map[string]string{"X-Tenant": "default", "X-Version": "1"},
+	})
+
+	calls := 0
+
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://restapi.local/things",
+		func(req *http.Request) (*http.Response, error) {
+			calls++
+
+			assert.Equal(t, "1", req.Header.Get("X-Version"))
+
+			return httpmock.NewStringResponse(http.StatusOK, req.Header.Get("X-Tenant")), nil
+		},
+	)
+
+	resp, err := client.DoRequest(t.Context(), &Request{
+		Method: http.MethodGet,
+		Path:   "/things",
+		Header: http.Header{"x-tenant": {"acme"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", resp.Body)
+
+	// Requests with additional headers are not cached.
+	resp, err = client.Do(t.Context(), http.MethodGet, "/things", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "default", resp.Body)
+	assert.Equal(t, 2, calls)
+}
+
+func TestAPIClientSensitiveKeys(t *testing.T) {
+	client := newMockClient(t, &ClientOptions{RateLimit: 100})
+
+	httpmock.RegisterResponder(
+		http.MethodPost,
+		"https://restapi.local/users",
+		httpmock.NewStringResponder(http.StatusConflict, `{"name":"foo","token":"secret"}`),
+	)
+
+	resp, err := client.DoRequest(t.Context(), &Request{
+		Method:        http.MethodPost,
+		Path:          "/users",
+		Body:          `{"name":"foo","token":"secret"}`,
+		SensitiveKeys: []string{"token"},
+	})
+	assert.ErrorIs(t, err, ErrUnexpectedResponseCode)
+	assert.NotContains(t, err.Error(), "secret")
+	assert.Contains(t, err.Error(), `"token":"(redacted)"`)
+	assert.Contains(t, resp.Body, "secret")
+}
+
 func newMockClient(t *testing.T, opts *ClientOptions) *RestClient {
 	t.Helper()
 
@@ -0,0 +1,170 @@
+package restclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"mime"
+	"slices"
+	"strings"
+)
+
+const (
+	FormatJSON      = "json"
+	FormatNDJSON    = "ndjson"
+	FormatForm      = "form"
+	FormatMultipart = "multipart"
+	FormatXML       = "xml"
+	FormatYAML      = "yaml"
+)
+
+var (
+	ErrRequestFormat        = errors.New("failed to encode request data")
+	ErrResponseFormat       = errors.New("failed to decode response data")
+	ErrUnsupportedFormat    = errors.New("unsupported format")
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+)
+
+// Codec encodes request data to and decodes response bodies from a wire format.
+type Codec interface {
+	// MediaTypes returns the media types handled by the codec.
+	MediaTypes() []string
+	// Encode encodes the data and returns the body and its content type.
+	Encode(data map[string]any) (string, string, error)
+	// Decode decodes the body to data that can be encoded as JSON.
+	Decode(body string) (any, error)
+}
+
+// RequestFormats returns the built-in formats to encode request data.
+func RequestFormats() []string {
+	return NewCodecs().Formats()
+}
+
+// ResponseFormats returns the built-in formats to decode response bodies.
+// Multipart bodies can only be encoded.
+func ResponseFormats() []string {
+	return slices.DeleteFunc(RequestFormats(), func(format string) bool {
+		return format == FormatMultipart
+	})
+}
+
+// Codecs is a registry of codecs mapped by format name and media type.
+type Codecs struct {
+	formats    map[string]Codec
+	mediaTypes map[string]string
+}
+
+// NewCodecs creates a registry with the codecs for all built-in formats.
+func NewCodecs() *Codecs {
+	c := &Codecs{
+		formats:    make(map[string]Codec),
+		mediaTypes: make(map[string]string),
+	}
+
+	c.Register(FormatJSON, &JSONCodec{})
+	c.Register(FormatNDJSON, &NDJSONCodec{})
+	c.Register(FormatForm, &FormCodec{})
+	c.Register(FormatMultipart, &MultipartCodec{})
+	c.Register(FormatXML, &XMLCodec{})
+	c.Register(FormatYAML, &YAMLCodec{})
+
+	return c
+}
+
+// Register adds the codec for the given format name and its media types.
+// Existing registrations are replaced.
+func (c *Codecs) Register(format string, codec Codec) {
+	c.formats[format] = codec
+
+	for _, mediaType := range codec.MediaTypes() {
+		c.mediaTypes[mediaType] = format
+	}
+}
+
+// Formats returns the sorted names of all registered formats.
+func (c *Codecs) Formats() []string {
+	return slices.Sorted(maps.Keys(c.formats))
+}
+
+// Get returns the codec of the given format name.
+func (c *Codecs) Get(format string) (Codec, error) {
+	if err := checkFormat(format, c.Formats()); err != nil {
+		return nil, err
+	}
+
+	return c.formats[format], nil
+}
+
+// checkFormat returns an error if the format is not one of the given formats.
+func checkFormat(format string, formats []string) error {
+	if !slices.Contains(formats, format) {
+		return fmt.Errorf("%w: '%s' not one of '%s'", ErrUnsupportedFormat, format, strings.Join(formats, "', '"))
+	}
+
+	return nil
+}
+
+// ForContentType returns the codec for the media type of the given content type.
+// Structured syntax suffixes like `+json` are mapped to the codec of the
+// respective format. An empty content type defaults to JSON.
+func (c *Codecs) ForContentType(contentType string) (Codec, error) {
+	if contentType == "" {
+		return c.Get(FormatJSON)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: '%s': %w", ErrUnsupportedMediaType, contentType, err)
+	}
+
+	if format, ok := c.mediaTypes[mediaType]; ok {
+		return c.Get(format)
+	}
+
+	if i := strings.LastIndex(mediaType, "+"); i >= 0 {
+		if codec, ok := c.formats[mediaType[i+1:]]; ok {
+			return codec, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: no codec for '%s': set the response format to decode it anyway",
+		ErrUnsupportedMediaType, mediaType)
+}
+
+// Resolve returns the codec of the given format, or the codec chosen by the
+// content type of the response if no format is given.
+func (c *Codecs) Resolve(format string, resp *Response) (Codec, error) {
+	if format != "" {
+		return c.Get(format)
+	}
+
+	return c.ForContentType(resp.Header.Get("Content-Type"))
+}
+
+// Decode decodes the response body with the codec of the given format and
+// returns it as JSON document. If no format is given, the codec is chosen by
+// the content type of the response.
+func (c *Codecs) Decode(format string, resp *Response) (string, error) {
+	codec, err := c.Resolve(format, resp)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrResponseFormat, err)
+	}
+
+	// Avoid a needless roundtrip for JSON documents.
+	if _, ok := codec.(*JSONCodec); ok {
+		return resp.Body, nil
+	}
+
+	result, err := codec.Decode(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := json.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrResponseFormat, err)
+	}
+
+	return string(b), nil
+}
@@ -0,0 +1,150 @@
+package restclient
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testCodec struct{}
+
+func (c *testCodec) MediaTypes() []string {
+	return []string{"text/csv"}
+}
+
+func (c *testCodec) Encode(_ map[string]any) (string, string, error) {
+	return "id\n1\n", "text/csv", nil
+}
+
+func (c *testCodec) Decode(_ string) (any, error) {
+	return map[string]any{"id": "1"}, nil
+}
+
+func TestCodecsForContentType(t *testing.T) {
+	codecs := NewCodecs()
+
+	tests := []struct {
+		name        string
+		contentType string
+		want        Codec
+		wantErr     error
+	}{
+		{name: "empty", contentType: "", want: &JSONCodec{}},
+		{name: "json", contentType: "application/json; charset=utf-8", want: &JSONCodec{}},
+		{name: "json suffix", contentType: "application/vnd.api+json", want: &JSONCodec{}},
+		{name: "problem json", contentType: "application/problem+json", want: &JSONCodec{}},
+		{name: "ndjson", contentType: "application/x-ndjson", want: &NDJSONCodec{}},
+		{name: "xml", contentType: "text/xml; charset=utf-8", want: &XMLCodec{}},
+		{name: "xml suffix", contentType: "application/atom+xml", want: &XMLCodec{}},
+		{name: "yaml", contentType: "application/x-yaml", want: &YAMLCodec{}},
+		{name: "form", contentType: "application/x-www-form-urlencoded", want: &FormCodec{}},
+		{name: "unsupported", contentType: "text/html; charset=utf-8", wantErr: ErrUnsupportedMediaType},
+		{name: "invalid", contentType: "/", wantErr: ErrUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := codecs.ForContentType(tt.contentType)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.IsType(t, tt.want, got)
+		})
+	}
+}
+
+func TestCodecsDecode(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      string
+		contentType string
+		body        string
+		want        string
+		wantErr     error
+	}{
+		{
+			name: "json",
+			body: `{"id": 1}`,
+			want: `{"id": 1}`,
+		},
+		{
+			name:        "yaml content type",
+			contentType: "application/yaml; charset=utf-8",
+			body:        "id: 1\n",
+			want:        `{"id":1}`,
+		},
+		{
+			name:        "xml content type",
+			contentType: "application/problem+xml",
+			body:        "<problem><id>1</id></problem>",
+			want:        `{"id":"1"}`,
+		},
+		{
+			name:        "ndjson content type",
+			contentType: "application/x-ndjson",
+			body:        "{\"id\": 1}\n{\"id\": 2}\n",
+			want:        `[{"id":1},{"id":2}]`,
+		},
+		{
+			name:        "format overrides content type",
+			format:      FormatYAML,
+			contentType: "text/html",
+			body:        "id: 1\n",
+			want:        `{"id":1}`,
+		},
+		{
+			name:        "unsupported content type",
+			contentType: "text/html",
+			body:        "<html></html>",
+			wantErr:     ErrUnsupportedMediaType,
+		},
+		{
+			name:    "invalid format",
+			format:  "invalid",
+			body:    `{"id": 1}`,
+			wantErr: ErrUnsupportedFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &Response{Body: tt.body, Header: http.Header{}}
+			if tt.contentType != "" {
+				resp.Header.Set("Content-Type", tt.contentType)
+			}
+
+			got, err := NewCodecs().Decode(tt.format, resp)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, ErrResponseFormat)
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, got)
+		})
+	}
+}
+
+func TestFormats(t *testing.T) {
+	assert.Equal(t, []string{"form", "json", "multipart", "ndjson", "xml", "yaml"}, RequestFormats())
+	assert.Equal(t, []string{"form", "json", "ndjson", "xml", "yaml"}, ResponseFormats())
+}
+
+func TestCodecsRegister(t *testing.T) {
+	codecs := NewCodecs()
+	codecs.Register("csv", &testCodec{})
+
+	assert.Equal(t, []string{"csv", "form", "json", "multipart", "ndjson", "xml", "yaml"}, codecs.Formats())
+
+	got, err := codecs.Decode("", &Response{Body: "id\n1\n", Header: http.Header{"Content-Type": {"text/csv"}}})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id": "1"}`, got)
+}
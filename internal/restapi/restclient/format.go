@@ -0,0 +1,244 @@
+package restclient
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"mime/multipart"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	ContentTypeJSON      = "application/json"
+	ContentTypeNDJSON    = "application/x-ndjson"
+	ContentTypeForm      = "application/x-www-form-urlencoded"
+	ContentTypeMultipart = "multipart/form-data"
+	ContentTypeXML       = "application/xml"
+	ContentTypeYAML      = "application/yaml"
+)
+
+// JSONCodec encodes and decodes JSON documents.
+type JSONCodec struct{}
+
+func (c *JSONCodec) MediaTypes() []string {
+	return []string{ContentTypeJSON, "text/json"}
+}
+
+func (c *JSONCodec) Encode(data map[string]any) (string, string, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %w", ErrRequestFormat, err)
+	}
+
+	return string(b), ContentTypeJSON, nil
+}
+
+func (c *JSONCodec) Decode(body string) (any, error) {
+	var result any
+
+	if err := json.Unmarshal([]byte(body), &result); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrResponseFormat, err)
+	}
+
+	return result, nil
+}
+
+// NDJSONCodec encodes and decodes newline-delimited JSON documents.
+// The documents of a response are decoded to an array.
+type NDJSONCodec struct{}
+
+func (c *NDJSONCodec) MediaTypes() []string {
+	return []string{ContentTypeNDJSON, "application/ndjson", "application/jsonl", "application/x-jsonlines"}
+}
+
+func (c *NDJSONCodec) Encode(data map[string]any) (string, string, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %w", ErrRequestFormat, err)
+	}
+
+	return string(b) + "\n", ContentTypeNDJSON, nil
+}
+
+func (c *NDJSONCodec) Decode(body string) (any, error) {
+	result := make([]any, 0)
+	scanner := bufio.NewScanner(strings.NewReader(body))
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var item any
+
+		if err := json.Unmarshal([]byte(line), &item); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrResponseFormat, err)
+		}
+
+		result = append(result, item)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrResponseFormat, err)
+	}
+
+	return result, nil
+}
+
+// FormCodec encodes and decodes URL-encoded form data. Nested objects are
+// encoded as `key[nested]` fields and arrays of scalars as repeated fields.
+// Decoded repeated fields are mapped to arrays.
+type FormCodec struct{}
+
+func (c *FormCodec) MediaTypes() []string {
+	return []string{ContentTypeForm}
+}
+
+func (c *FormCodec) Encode(data map[string]any) (string, string, error) {
+	return formValues(data).Encode(), ContentTypeForm, nil
+}
+
+func (c *FormCodec) Decode(body string) (any, error) {
+	values, err := url.ParseQuery(strings.TrimSpace(body))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrResponseFormat, err)
+	}
+
+	result := make(map[string]any, len(values))
+
+	for key, items := range values {
+		if len(items) == 1 {
+			result[key] = items[0]
+
+			continue
+		}
+
+		list := make([]any, 0, len(items))
+		for _, item := range items {
+			list = append(list, item)
+		}
+
+		result[key] = list
+	}
+
+	return result, nil
+}
+
+// MultipartCodec encodes multipart form data like the FormCodec.
+// Files are attached as file parts, mapped by their form field name.
+// Decoding multipart responses is not supported.
+type MultipartCodec struct {
+	Files map[string]string
+}
+
+func (c *MultipartCodec) MediaTypes() []string {
+	return []string{ContentTypeMultipart}
+}
+
+func (c *MultipartCodec) Encode(data map[string]any) (string, string, error) {
+	var buffer bytes.Buffer
+
+	writer := multipart.NewWriter(&buffer)
+	values := formValues(data)
+
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		for _, value := range values[key] {
+			if err := writer.WriteField(key, value); err != nil {
+				return "", "", fmt.Errorf("%w: %w", ErrRequestFormat, err)
+			}
+		}
+	}
+
+	for _, field := range slices.Sorted(maps.Keys(c.Files)) {
+		if err := writeFilePart(writer, field, c.Files[field]); err != nil {
+			return "", "", fmt.Errorf("%w: file '%s': %w", ErrRequestFormat, c.Files[field], err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", "", fmt.Errorf("%w: %w", ErrRequestFormat, err)
+	}
+
+	return buffer.String(), writer.FormDataContentType(), nil
+}
+
+func (c *MultipartCodec) Decode(_ string) (any, error) {
+	return nil, fmt.Errorf("%w: %w: decoding '%s'", ErrResponseFormat, ErrUnsupportedFormat, FormatMultipart)
+}
+
+// writeFilePart copies the content of the local file to a new file part.
+func writeFilePart(writer *multipart.Writer, field, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	part, err := writer.CreateFormFile(field, filepath.Base(path))
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(part, file)
+
+	return err
+}
+
+// formValues flattens the data to form values.
+func formValues(data map[string]any) url.Values {
+	values := url.Values{}
+
+	for key, value := range data {
+		addFormValue(values, key, value)
+	}
+
+	return values
+}
+
+// addFormValue adds the value under the given key. Objects are added as
+// `key[nested]`, arrays of scalars as repeated keys and arrays of objects
+// or arrays as `key[index]`.
+func addFormValue(values url.Values, key string, value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, nested := range v {
+			addFormValue(values, fmt.Sprintf("%s[%s]", key, k), nested)
+		}
+	case []any:
+		for i, item := range v {
+			switch item.(type) {
+			case map[string]any, []any:
+				addFormValue(values, fmt.Sprintf("%s[%d]", key, i), item)
+			default:
+				addFormValue(values, key, item)
+			}
+		}
+	default:
+		values.Add(key, formatValue(v))
+	}
+}
+
+// formatValue returns the string representation of a scalar value.
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
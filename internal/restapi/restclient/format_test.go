@@ -0,0 +1,193 @@
+package restclient
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodecEncode(t *testing.T) {
+	tests := []struct {
+		name            string
+		codec           Codec
+		data            map[string]any
+		wantBody        string
+		wantContentType string
+	}{
+		{
+			name:            "json",
+			codec:           &JSONCodec{},
+			data:            map[string]any{"name": "foo", "size": float64(1)},
+			wantBody:        `{"name":"foo","size":1}`,
+			wantContentType: ContentTypeJSON,
+		},
+		{
+			name:            "ndjson",
+			codec:           &NDJSONCodec{},
+			data:            map[string]any{"name": "foo"},
+			wantBody:        "{\"name\":\"foo\"}\n",
+			wantContentType: ContentTypeNDJSON,
+		},
+		{
+			name:            "form",
+			codec:           &FormCodec{},
+			data:            map[string]any{"name": "foo bar", "size": float64(1.5), "enabled": true},
+			wantBody:        "enabled=true&name=foo+bar&size=1.5",
+			wantContentType: ContentTypeForm,
+		},
+		{
+			name:  "form nested",
+			codec: &FormCodec{},
+			data: map[string]any{
+				"tags":  []any{"a", "b"},
+				"owner": map[string]any{"name": "foo"},
+				"rules": []any{map[string]any{"port": float64(80)}},
+			},
+			wantBody:        "owner%5Bname%5D=foo&rules%5B0%5D%5Bport%5D=80&tags=a&tags=b",
+			wantContentType: ContentTypeForm,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, contentType, err := tt.codec.Encode(tt.data)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantBody, body)
+			assert.Equal(t, tt.wantContentType, contentType)
+		})
+	}
+}
+
+func TestCodecDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		codec   Codec
+		body    string
+		want    any
+		wantErr error
+	}{
+		{
+			name:  "json",
+			codec: &JSONCodec{},
+			body:  `{"name": "foo"}`,
+			want:  map[string]any{"name": "foo"},
+		},
+		{
+			name:  "ndjson",
+			codec: &NDJSONCodec{},
+			body:  "{\"id\": 1}\n\n{\"id\": 2}\n",
+			want:  []any{map[string]any{"id": float64(1)}, map[string]any{"id": float64(2)}},
+		},
+		{
+			name:    "invalid ndjson",
+			codec:   &NDJSONCodec{},
+			body:    "{\"id\": 1}\n{\"id\":",
+			wantErr: ErrResponseFormat,
+		},
+		{
+			name:  "form",
+			codec: &FormCodec{},
+			body:  "name=foo+bar&tags=a&tags=b",
+			want:  map[string]any{"name": "foo bar", "tags": []any{"a", "b"}},
+		},
+		{
+			name:    "multipart",
+			codec:   &MultipartCodec{},
+			body:    "--boundary--",
+			wantErr: ErrUnsupportedFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.codec.Decode(tt.body)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMultipartCodec(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "report.csv")
+	assert.NoError(t, os.WriteFile(file, []byte("a,b\n1,2\n"), 0o600))
+
+	codec := &MultipartCodec{Files: map[string]string{"upload": file}}
+
+	body, contentType, err := codec.Encode(map[string]any{"name": "foo", "owner": map[string]any{"id": float64(1)}})
+	assert.NoError(t, err)
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	assert.NoError(t, err)
+	assert.Equal(t, ContentTypeMultipart, mediaType)
+
+	parts := make(map[string]string)
+	filenames := make(map[string]string)
+	reader := multipart.NewReader(strings.NewReader(body), params["boundary"])
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+
+		assert.NoError(t, err)
+
+		content, _ := io.ReadAll(part)
+		parts[part.FormName()] = string(content)
+		filenames[part.FormName()] = part.FileName()
+	}
+
+	assert.Equal(t, map[string]string{"name": "foo", "owner[id]": "1", "upload": "a,b\n1,2\n"}, parts)
+	assert.Equal(t, "report.csv", filenames["upload"])
+
+	codec.Files["upload"] = file + ".missing"
+
+	_, _, err = codec.Encode(nil)
+	assert.ErrorIs(t, err, ErrRequestFormat)
+}
+
+func TestAPIClientRequestFormat(t *testing.T) {
+	_, err := New(t.Context(), &ClientOptions{Endpoint: "https://restapi.local", RequestFormat: "invalid"})
+	assert.ErrorIs(t, err, ErrInvalidClientOptions)
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+
+	_, err = New(t.Context(), &ClientOptions{Endpoint: "https://restapi.local", ResponseFormat: "invalid"})
+	assert.ErrorIs(t, err, ErrInvalidClientOptions)
+
+	_, err = New(t.Context(), &ClientOptions{Endpoint: "https://restapi.local", ResponseFormat: FormatMultipart})
+	assert.ErrorIs(t, err, ErrInvalidClientOptions)
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+
+	client := newMockClient(t, &ClientOptions{RequestFormat: FormatForm})
+
+	httpmock.RegisterResponder(
+		http.MethodPost,
+		"https://restapi.local/things",
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+
+			assert.Equal(t, ContentTypeForm, req.Header.Get("Content-Type"))
+			assert.Equal(t, "name=foo", string(body))
+
+			return httpmock.NewStringResponse(http.StatusOK, "OK"), nil
+		},
+	)
+
+	_, _, err = client.SendRequestWithContentType(t.Context(), http.MethodPost, "/things", "name=foo", ContentTypeForm)
+	assert.NoError(t, err)
+}
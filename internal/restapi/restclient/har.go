@@ -0,0 +1,535 @@
+package restclient
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// harVersion is the version of the HAR format of recorded files.
+	harVersion = "1.2"
+	// harTrailer closes the entries of a HAR file, as formatted by json.MarshalIndent.
+	harTrailer = "\n    ]\n  }\n}"
+	// harEntryIndent is the indentation of the entries of a HAR file.
+	harEntryIndent = "      "
+)
+
+var ErrRecordHAR = errors.New("failed to record HAR file")
+
+// harFile is a decoded HAR file. The other properties of the file and its log
+// are kept as they are, the entries are appended to.
+type harFile struct {
+	root    map[string]json.RawMessage
+	log     map[string]json.RawMessage
+	entries []any
+}
+
+// object returns the JSON object of the file. The entries are written last
+// to allow appending further entries.
+func (f *harFile) object() harObject {
+	log := append(newHARObject(f.log, harLogKeys()), harField{Key: "entries", Value: f.entries})
+
+	return append(newHARObject(f.root, nil), harField{Key: "log", Value: log})
+}
+
+// harLogKeys is the order of the known properties of the log of a HAR file.
+func harLogKeys() []string {
+	return []string{"version", "creator", "browser", "pages", "comment"}
+}
+
+// harObject is a JSON object that keeps the order of its fields.
+type harObject []harField
+
+type harField struct {
+	Key   string
+	Value any
+}
+
+func (o harObject) MarshalJSON() ([]byte, error) {
+	buf := bytes.NewBufferString("{")
+
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// newHARObject returns the fields in the given order of keys, followed by
+// all other fields sorted by key.
+func newHARObject(fields map[string]json.RawMessage, keys []string) harObject {
+	result := make(harObject, 0, len(fields))
+
+	for _, key := range keys {
+		if value, ok := fields[key]; ok {
+			result = append(result, harField{Key: key, Value: value})
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(fields)) {
+		if !slices.Contains(keys, key) {
+			result = append(result, harField{Key: key, Value: fields[key]})
+		}
+	}
+
+	return result
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	ServerIPAddress string      `json:"serverIPAddress,omitempty"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+// harTimings holds the durations of the phases of a request in milliseconds,
+// or -1 if a phase does not apply to the request, e.g. the DNS lookup of a
+// reused connection.
+type harTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl"`
+}
+
+// harRecorder appends the recorded entries to a HAR file. Every entry is written
+// when it is recorded, as the provider process might be stopped by Terraform at
+// any time, and entries are appended to an existing file to keep the requests
+// of all Terraform runs, e.g. of both plan and apply. The file is locked while
+// writing, as it might be shared with the provider processes of other aliases.
+type harRecorder struct {
+	path    string
+	creator harCreator
+
+	mu     sync.Mutex
+	loaded bool
+}
+
+func newHARRecorder(path, version string) *harRecorder {
+	return &harRecorder{
+		path:    path,
+		creator: harCreator{Name: serviceName, Version: version},
+	}
+}
+
+// record appends the entry to the HAR file. The existing file is read and
+// rewritten only by the first entry of the recorder, all further entries are
+// inserted in front of the closing brackets of the file. An existing file that
+// is no valid HAR file is never overwritten.
+func (h *harRecorder) record(entry harEntry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	f, err := os.OpenFile(h.path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRecordHAR, err)
+	}
+	defer f.Close()
+
+	if err := lockFile(f); err != nil {
+		return fmt.Errorf("%w: %w", ErrRecordHAR, err)
+	}
+	defer unlockFile(f) //nolint:errcheck
+
+	// Fall back to rewrite the file if it was modified by others.
+	if h.loaded {
+		ok, err := appendHAREntry(f, entry)
+		if err != nil || ok {
+			return err
+		}
+	}
+
+	if err := h.rewrite(f, entry); err != nil {
+		return err
+	}
+
+	h.loaded = true
+
+	return nil
+}
+
+// rewrite reads the HAR file and writes it again with the entry appended. All
+// properties of the file and its entries are kept, including custom fields.
+func (h *harRecorder) rewrite(f *os.File, entry harEntry) error {
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRecordHAR, err)
+	}
+
+	file, err := h.decode(data)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrRecordHAR, h.path, err)
+	}
+
+	file.entries = append(file.entries, entry)
+
+	data, err = json.MarshalIndent(file.object(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRecordHAR, err)
+	}
+
+	if _, err := f.WriteAt(data, 0); err != nil {
+		return fmt.Errorf("%w: %w", ErrRecordHAR, err)
+	}
+
+	if err := f.Truncate(int64(len(data))); err != nil {
+		return fmt.Errorf("%w: %w", ErrRecordHAR, err)
+	}
+
+	return nil
+}
+
+// decode decodes the HAR file, or returns a new file if data is empty.
+func (h *harRecorder) decode(data []byte) (*harFile, error) {
+	file := &harFile{root: map[string]json.RawMessage{}, log: map[string]json.RawMessage{}}
+
+	if len(data) == 0 {
+		file.log["version"], _ = json.Marshal(harVersion)
+		file.log["creator"], _ = json.Marshal(h.creator)
+
+		return file, nil
+	}
+
+	if err := json.Unmarshal(data, &file.root); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(file.root["log"], &file.log); err != nil {
+		return nil, fmt.Errorf("invalid log: %w", err)
+	}
+
+	var entries []json.RawMessage
+
+	if value, ok := file.log["entries"]; ok {
+		if err := json.Unmarshal(value, &entries); err != nil {
+			return nil, fmt.Errorf("invalid entries: %w", err)
+		}
+	}
+
+	for _, entry := range entries {
+		file.entries = append(file.entries, entry)
+	}
+
+	delete(file.root, "log")
+	delete(file.log, "entries")
+
+	return file, nil
+}
+
+// appendHAREntry inserts the entry in front of the closing brackets of the
+// entries of a HAR file as written by harRecorder.rewrite. Returns false if
+// the file does not end with the last entry and the expected brackets.
+func appendHAREntry(f *os.File, entry harEntry) (bool, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", ErrRecordHAR, err)
+	}
+
+	tail := make([]byte, min(info.Size(), int64(len(harTrailer)+1)))
+	offset := info.Size() - int64(len(tail))
+
+	if _, err := f.ReadAt(tail, offset); err != nil {
+		return false, fmt.Errorf("%w: %w", ErrRecordHAR, err)
+	}
+
+	// The trailer has to follow the closing brace of the last entry.
+	if !bytes.Equal(tail, []byte("}"+harTrailer)) {
+		return false, nil
+	}
+
+	data, err := json.MarshalIndent(entry, harEntryIndent, "  ")
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", ErrRecordHAR, err)
+	}
+
+	data = slices.Concat([]byte(",\n"+harEntryIndent), data, []byte(harTrailer))
+
+	if _, err := f.WriteAt(data, offset+1); err != nil {
+		return false, fmt.Errorf("%w: %w", ErrRecordHAR, err)
+	}
+
+	return true, nil
+}
+
+// harTimer records the points in time of the phases of a request.
+type harTimer struct {
+	started time.Time
+	blocked time.Duration
+	proto   string
+
+	mu           sync.Mutex
+	dnsStart     time.Time
+	dnsDone      time.Time
+	connectStart time.Time
+	connectDone  time.Time
+	tlsStart     time.Time
+	tlsDone      time.Time
+	gotConn      time.Time
+	wroteRequest time.Time
+	firstByte    time.Time
+	remoteAddr   string
+}
+
+func newHARTimer() *harTimer {
+	return &harTimer{started: time.Now()}
+}
+
+// now sets the given point in time of the timer to the current time.
+func (t *harTimer) now(point *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	*point = time.Now()
+}
+
+// clientTrace returns the hooks to record the phases of the request.
+func (t *harTimer) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart:             func(httptrace.DNSStartInfo) { t.now(&t.dnsStart) },
+		DNSDone:              func(httptrace.DNSDoneInfo) { t.now(&t.dnsDone) },
+		ConnectStart:         func(_, _ string) { t.now(&t.connectStart) },
+		ConnectDone:          func(_, _ string, _ error) { t.now(&t.connectDone) },
+		TLSHandshakeStart:    func() { t.now(&t.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { t.now(&t.tlsDone) },
+		WroteRequest:         func(httptrace.WroteRequestInfo) { t.now(&t.wroteRequest) },
+		GotFirstResponseByte: func() { t.now(&t.firstByte) },
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.now(&t.gotConn)
+
+			t.mu.Lock()
+			defer t.mu.Unlock()
+
+			if info.Conn != nil {
+				t.remoteAddr = info.Conn.RemoteAddr().String()
+			}
+		},
+	}
+}
+
+// timings returns the durations of the request phases until the given end of
+// the request. The rate limit wait is reported as time spent blocked.
+func (t *harTimer) timings(end time.Time) harTimings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	connectDone := t.connectDone
+	if !t.tlsDone.IsZero() {
+		connectDone = t.tlsDone
+	}
+
+	return harTimings{
+		Blocked: milliseconds(t.blocked),
+		DNS:     harDuration(t.dnsStart, t.dnsDone),
+		Connect: harDuration(t.connectStart, connectDone),
+		SSL:     harDuration(t.tlsStart, t.tlsDone),
+		Send:    max(harDuration(t.gotConn, t.wroteRequest), 0),
+		Wait:    max(harDuration(t.wroteRequest, t.firstByte), 0),
+		Receive: max(harDuration(t.firstByte, end), 0),
+	}
+}
+
+// harDuration returns the duration between both points in time in milliseconds,
+// or -1 if any of them is not set.
+func harDuration(start, end time.Time) float64 {
+	if start.IsZero() || end.IsZero() {
+		return -1
+	}
+
+	return milliseconds(end.Sub(start))
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// recordHAR records the exchange of the request and the response, if any, to
+// the HAR file. Credentials, the values of sensitive headers and the sensitive
+// keys of the bodies are redacted. Failures are logged, but never fail the request.
+func (rc *RestClient) recordHAR(
+	ctx context.Context, req *http.Request, r *Request, timer *harTimer, resp *Response, err error,
+) {
+	end := time.Now()
+	redact := rc.secretsReplacer(req.Header)
+
+	entry := harEntry{
+		StartedDateTime: timer.started.Format(time.RFC3339Nano),
+		Request: harRequest{
+			Method:      req.Method,
+			URL:         redact.Replace(req.URL.String()),
+			HTTPVersion: req.Proto,
+			Cookies:     harCookies(req.Cookies()),
+			Headers:     harHeaders(rc.RedactHeaders(req.Header), redact),
+			QueryString: harHeaders(http.Header(req.URL.Query()), redact),
+			HeadersSize: -1,
+			BodySize:    len(r.Body),
+		},
+		Response: harResponse{
+			Cookies:     []harNameValue{},
+			Headers:     []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Timings: timer.timings(end),
+	}
+
+	if r.Body != "" {
+		entry.Request.PostData = &harPostData{
+			MimeType: req.Header.Get("Content-Type"),
+			Text:     redact.Replace(utils.RedactJSONString(r.Body, r.SensitiveKeys)),
+		}
+	}
+
+	if resp != nil && resp.StatusCode != 0 {
+		entry.Response = harResponse{
+			Status:      resp.StatusCode,
+			StatusText:  http.StatusText(resp.StatusCode),
+			HTTPVersion: timer.proto,
+			Cookies:     harCookies((&http.Response{Header: resp.Header}).Cookies()),
+			Headers:     harHeaders(rc.RedactHeaders(resp.Header), redact),
+			Content: harContent{
+				Size:     len(resp.Body),
+				MimeType: resp.Header.Get("Content-Type"),
+				Text:     redact.Replace(utils.RedactJSONString(resp.Body, r.SensitiveKeys)),
+			},
+			HeadersSize: -1,
+			BodySize:    len(resp.Body),
+		}
+	}
+
+	if err != nil {
+		entry.Comment = redact.Replace(err.Error())
+	}
+
+	timer.mu.Lock()
+	entry.ServerIPAddress, _, _ = net.SplitHostPort(timer.remoteAddr)
+	timer.mu.Unlock()
+
+	for _, d := range []float64{
+		entry.Timings.Blocked, entry.Timings.DNS, entry.Timings.Connect,
+		entry.Timings.Send, entry.Timings.Wait, entry.Timings.Receive,
+	} {
+		entry.Time += max(d, 0)
+	}
+
+	if err := rc.har.record(entry); err != nil {
+		tflog.Warn(ctx, err.Error())
+	}
+}
+
+// harHeaders returns the headers as sorted list with the secrets of all
+// values redacted.
+func harHeaders(header http.Header, redact *strings.Replacer) []harNameValue {
+	result := []harNameValue{}
+
+	for name, values := range header {
+		for _, value := range values {
+			result = append(result, harNameValue{Name: name, Value: redact.Replace(value)})
+		}
+	}
+
+	slices.SortStableFunc(result, func(a, b harNameValue) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return result
+}
+
+// harCookies returns the names of the cookies with redacted values.
+func harCookies(cookies []*http.Cookie) []harNameValue {
+	result := make([]harNameValue, 0, len(cookies))
+
+	for _, cookie := range cookies {
+		result = append(result, harNameValue{Name: cookie.Name, Value: redactString(cookie.Value)})
+	}
+
+	return result
+}
@@ -0,0 +1,19 @@
+//go:build unix
+
+package restclient
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile acquires an exclusive lock of the file, which blocks until the
+// lock is released by other processes.
+func lockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+}
+
+// unlockFile releases the lock of the file.
+func unlockFile(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,285 @@
+package restclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordedHAR is the part of a HAR file written by the recorder.
+type recordedHAR struct {
+	Log struct {
+		Version string     `json:"version"`
+		Creator harCreator `json:"creator"`
+		Entries []harEntry `json:"entries"`
+	} `json:"log"`
+}
+
+func readHARFile(t *testing.T, path string) (recordedHAR, string) {
+	t.Helper()
+
+	var file recordedHAR
+
+	data, err := os.ReadFile(path)
+	if assert.NoError(t, err) {
+		assert.NoError(t, json.Unmarshal(data, &file))
+	}
+
+	return file, string(data)
+}
+
+func TestRecordHAR(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "requests.har")
+	client := newMockClient(t, &ClientOptions{
+		Username:         "admin",
+		Password:         "basic-secret",
+		Headers:          map[string]string{"X-Api-Key": "api-key-secret", "X-Tenant": "acme"},
+		SensitiveHeaders: []string{"x-api-key"},
+		RateLimit:        100,
+		RecordHARFile:    path,
+		Version:          "1.0.0",
+	})
+
+	httpmock.RegisterResponder(
+		http.MethodPost,
+		"https://restapi.local/users",
+		func(_ *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(http.StatusCreated, `{"id":"1","name":"foo","token":"token-secret"}`)
+			resp.Header.Set("Content-Type", "application/json")
+			resp.Header.Set("Set-Cookie", "session=cookie-secret")
+
+			return resp, nil
+		},
+	)
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://restapi.local/users/2",
+		httpmock.NewStringResponder(http.StatusNotFound, `{"error":"not found","token":"token-secret"}`),
+	)
+
+	_, err := client.DoRequest(t.Context(), &Request{
+		Method:        http.MethodPost,
+		Path:          "/users?dry_run=true",
+		Body:          `{"name":"foo","password":"password-secret"}`,
+		ContentType:   ContentTypeJSON,
+		SensitiveKeys: []string{"password", "token"},
+	})
+	assert.NoError(t, err)
+
+	_, err = client.DoRequest(t.Context(), &Request{
+		Method:        http.MethodGet,
+		Path:          "/users/2",
+		SensitiveKeys: []string{"token"},
+	})
+	assert.ErrorIs(t, err, ErrUnexpectedResponseCode)
+
+	file, raw := readHARFile(t, path)
+
+	for _, secret := range []string{"basic-secret", "api-key-secret", "password-secret", "token-secret", "cookie-secret"} {
+		assert.NotContains(t, raw, secret)
+	}
+
+	assert.Equal(t, "1.2", file.Log.Version)
+	assert.Equal(t, harCreator{Name: "terraform-provider-restapi", Version: "1.0.0"}, file.Log.Creator)
+
+	if assert.Len(t, file.Log.Entries, 2) {
+		entry := file.Log.Entries[0]
+
+		assert.Equal(t, http.MethodPost, entry.Request.Method)
+		assert.Equal(t, "https://restapi.local/users?dry_run=true", entry.Request.URL)
+		assert.Equal(t, []harNameValue{{Name: "dry_run", Value: "true"}}, entry.Request.QueryString)
+		assert.Equal(t, []harNameValue{
+			{Name: "Authorization", Value: utils.RedactedValue},
+			{Name: "Content-Type", Value: ContentTypeJSON},
+			{Name: "X-Api-Key", Value: utils.RedactedValue},
+			{Name: "X-Tenant", Value: "acme"},
+		}, entry.Request.Headers)
+		assert.Equal(t, &harPostData{
+			MimeType: ContentTypeJSON,
+			Text:     fmt.Sprintf(`{"name":"foo","password":"%s"}`, utils.RedactedValue),
+		}, entry.Request.PostData)
+
+		assert.Equal(t, http.StatusCreated, entry.Response.Status)
+		assert.Equal(t, "Created", entry.Response.StatusText)
+		assert.Equal(t, []harNameValue{{Name: "session", Value: utils.RedactedValue}}, entry.Response.Cookies)
+		assert.Equal(t, harContent{
+			Size:     46,
+			MimeType: "application/json",
+			Text:     fmt.Sprintf(`{"id":"1","name":"foo","token":"%s"}`, utils.RedactedValue),
+		}, entry.Response.Content)
+		assert.GreaterOrEqual(t, entry.Timings.Blocked, float64(0))
+		assert.GreaterOrEqual(t, entry.Time, entry.Timings.Blocked)
+
+		entry = file.Log.Entries[1]
+
+		assert.Equal(t, http.MethodGet, entry.Request.Method)
+		assert.Nil(t, entry.Request.PostData)
+		assert.Equal(t, http.StatusNotFound, entry.Response.Status)
+		assert.Contains(t, entry.Comment, ErrUnexpectedResponseCode.Error())
+	}
+}
+
+func TestRecordHARTimings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "requests.har")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, _ := New(t.Context(), &ClientOptions{Endpoint: server.URL, RecordHARFile: path})
+
+	_, err := client.Do(t.Context(), http.MethodGet, "/things", "", "")
+	assert.NoError(t, err)
+
+	_, err = client.Do(t.Context(), http.MethodGet, "/things", "", "")
+	assert.NoError(t, err)
+
+	file, _ := readHARFile(t, path)
+
+	if assert.Len(t, file.Log.Entries, 2) {
+		first, second := file.Log.Entries[0], file.Log.Entries[1]
+
+		assert.Equal(t, "HTTP/1.1", first.Response.HTTPVersion)
+		assert.Equal(t, "127.0.0.1", first.ServerIPAddress)
+		assert.GreaterOrEqual(t, first.Timings.Connect, float64(0))
+		assert.Equal(t, float64(-1), first.Timings.SSL)
+
+		// The connection of the first request is reused.
+		assert.Equal(t, float64(-1), second.Timings.Connect)
+		assert.GreaterOrEqual(t, second.Timings.Wait, float64(0))
+	}
+}
+
+func TestRecordHARAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "requests.har")
+
+	for range 2 {
+		client := newMockClient(t, &ClientOptions{RecordHARFile: path})
+
+		httpmock.RegisterResponder(
+			http.MethodGet,
+			"https://restapi.local/things",
+			httpmock.NewStringResponder(http.StatusOK, ""),
+		)
+
+		_, err := client.Do(t.Context(), http.MethodGet, "/things", "", "")
+		assert.NoError(t, err)
+	}
+
+	file, _ := readHARFile(t, path)
+
+	assert.Len(t, file.Log.Entries, 2)
+}
+
+func TestRecordHARForeignFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "requests.har")
+	foreign := `{
+  "_custom": 1,
+  "log": {
+    "version": "1.2",
+    "creator": {"name": "browser", "version": "1.0"},
+    "browser": {"name": "browser", "version": "1.0"},
+    "pages": [{"id": "page_1", "title": "Page"}],
+    "comment": "exported",
+    "entries": [{"pageref": "page_1", "_priority": "High", "time": 1}]
+  }
+}`
+	assert.NoError(t, os.WriteFile(path, []byte(foreign), 0o600))
+
+	client := newMockClient(t, &ClientOptions{RecordHARFile: path})
+
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://restapi.local/things",
+		httpmock.NewStringResponder(http.StatusOK, ""),
+	)
+
+	_, err := client.Do(t.Context(), http.MethodGet, "/things", "", "")
+	assert.NoError(t, err)
+
+	var file map[string]any
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(data, &file))
+
+	log, _ := file["log"].(map[string]any)
+	entries, _ := log["entries"].([]any)
+
+	assert.Equal(t, float64(1), file["_custom"])
+	assert.Equal(t, "exported", log["comment"])
+	assert.Equal(t, map[string]any{"name": "browser", "version": "1.0"}, log["browser"])
+	assert.Equal(t, []any{map[string]any{"id": "page_1", "title": "Page"}}, log["pages"])
+
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, map[string]any{"pageref": "page_1", "_priority": "High", "time": float64(1)}, entries[0])
+	}
+}
+
+func TestRecordHARInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "requests.har")
+	assert.NoError(t, os.WriteFile(path, []byte("invalid"), 0o600))
+
+	client := newMockClient(t, &ClientOptions{RecordHARFile: path})
+
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://restapi.local/things",
+		httpmock.NewStringResponder(http.StatusOK, ""),
+	)
+
+	_, err := client.Do(t.Context(), http.MethodGet, "/things", "", "")
+	assert.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "invalid", string(data))
+}
+
+func TestRecordHARShared(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "requests.har")
+	clients := []*RestClient{
+		newMockClient(t, &ClientOptions{RecordHARFile: path, RateLimit: 1000}),
+		newMockClient(t, &ClientOptions{RecordHARFile: path, RateLimit: 1000}),
+	}
+
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://restapi.local/things",
+		httpmock.NewStringResponder(http.StatusOK, `{"id": "1"}`),
+	)
+
+	var wg sync.WaitGroup
+
+	for _, client := range clients {
+		wg.Go(func() {
+			for range 10 {
+				_, err := client.Do(t.Context(), http.MethodGet, "/things", "", "")
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	file, raw := readHARFile(t, path)
+
+	assert.Len(t, file.Log.Entries, 20)
+
+	// The appended entries are formatted like the rewritten file.
+	data, err := json.MarshalIndent(file, "", "  ")
+	assert.NoError(t, err)
+	assert.Equal(t, string(data), raw)
+}
@@ -0,0 +1,121 @@
+package restclient
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// isSensitiveHeader reports whether the values of the header are redacted
+// from logs. Besides the configured sensitive headers, the headers holding
+// credentials and cookies are always sensitive.
+func (rc *RestClient) isSensitiveHeader(name string) bool {
+	name = http.CanonicalHeaderKey(name)
+
+	switch name {
+	case "Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie":
+		return true
+	}
+
+	return slices.ContainsFunc(rc.Options.SensitiveHeaders, func(header string) bool {
+		return http.CanonicalHeaderKey(header) == name
+	})
+}
+
+// RedactHeaders returns a copy of the headers with the values of sensitive
+// headers replaced by utils.RedactedValue.
+func (rc *RestClient) RedactHeaders(header http.Header) http.Header {
+	result := header.Clone()
+
+	for name, values := range result {
+		if !rc.isSensitiveHeader(name) {
+			continue
+		}
+
+		for i := range values {
+			values[i] = utils.RedactedValue
+		}
+	}
+
+	return result
+}
+
+// maskSecrets returns a context with a logger that masks the credentials of
+// the client and the values of sensitive headers in the given headers from
+// all log messages and fields, e.g. of headers derived from them.
+func (rc *RestClient) maskSecrets(ctx context.Context, header http.Header) context.Context {
+	secrets := rc.secrets(header)
+	if len(secrets) == 0 {
+		return ctx
+	}
+
+	return tflog.MaskLogStrings(ctx, secrets...)
+}
+
+// secretsReplacer returns a replacer of the secrets returned by secrets with
+// utils.RedactedValue.
+func (rc *RestClient) secretsReplacer(header http.Header) *strings.Replacer {
+	secrets := rc.secrets(header)
+
+	// Replace longer secrets first, as they might contain shorter ones.
+	slices.SortStableFunc(secrets, func(a, b string) int {
+		return len(b) - len(a)
+	})
+
+	oldnew := make([]string, 0, 2*len(secrets))
+
+	for _, secret := range secrets {
+		oldnew = append(oldnew, secret, utils.RedactedValue)
+	}
+
+	return strings.NewReplacer(oldnew...)
+}
+
+// secrets returns the credentials of the client and the values of sensitive
+// headers in the given headers.
+func (rc *RestClient) secrets(header http.Header) []string {
+	opts := rc.Options
+	secrets := []string{opts.Password, opts.KeyString}
+
+	if opts.OAuthClientCredentials != nil {
+		secrets = append(secrets, opts.OAuthClientCredentials.ClientSecret)
+	}
+
+	for name, value := range opts.Headers {
+		if rc.isSensitiveHeader(name) {
+			secrets = append(secrets, value)
+		}
+	}
+
+	for name, values := range header {
+		if !rc.isSensitiveHeader(name) {
+			continue
+		}
+
+		for _, value := range values {
+			// Mask the credentials of authorization headers separately,
+			// e.g. the token of `Bearer <token>`.
+			_, credentials, _ := strings.Cut(value, " ")
+			secrets = append(secrets, value, credentials)
+		}
+	}
+
+	return slices.DeleteFunc(secrets, func(secret string) bool {
+		return secret == ""
+	})
+}
+
+// redactString returns utils.RedactedValue, or an empty string if the value
+// is empty.
+func redactString(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	return utils.RedactedValue
+}
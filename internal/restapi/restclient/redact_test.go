@@ -0,0 +1,112 @@
+package restclient
+
+import (
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactLogs(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *ClientOptions
+		header  http.Header
+		secrets []string
+	}{
+		{
+			name: "basic auth",
+			opts: &ClientOptions{
+				Username: "admin",
+				Password: "basic-secret",
+			},
+			secrets: []string{"basic-secret", base64.StdEncoding.EncodeToString([]byte("admin:basic-secret"))},
+		},
+		{
+			name: "bearer token",
+			opts: &ClientOptions{
+				OAuthClientCredentials: &OAuthCredentials{
+					ClientID:      "client",
+					ClientSecret:  "client-secret",
+					TokenEndpoint: "https://restapi.local/token",
+				},
+			},
+			secrets: []string{"client-secret", "access-token"},
+		},
+		{
+			name: "sensitive headers",
+			opts: &ClientOptions{
+				Headers:          map[string]string{"X-Api-Key": "api-key-secret", "X-Tenant": "acme"},
+				SensitiveHeaders: []string{"x-api-key"},
+			},
+			header: http.Header{
+				"Authorization": {"Bearer header-token"},
+				"Cookie":        {"session=cookie-secret"},
+			},
+			secrets: []string{"api-key-secret", "header-token", "cookie-secret", "response-cookie-secret"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newMockClient(t, tt.opts)
+			ctx, output := testutils.SetupRootLogger()
+
+			httpmock.RegisterResponder(
+				http.MethodPost,
+				"https://restapi.local/token",
+				httpmock.NewStringResponder(http.StatusOK,
+					`{"access_token":"access-token","token_type":"bearer","expires_in":3600}`).
+					HeaderSet(http.Header{"Content-Type": {"application/json"}}),
+			)
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				"https://restapi.local/things",
+				func(req *http.Request) (*http.Response, error) {
+					resp := httpmock.NewStringResponse(http.StatusOK, req.Header.Get("Authorization"))
+					resp.Header.Set("Set-Cookie", "session=response-cookie-secret")
+
+					return resp, nil
+				},
+			)
+
+			_, err := client.DoRequest(ctx, &Request{Method: http.MethodGet, Path: "/things", Header: tt.header})
+			assert.NoError(t, err)
+
+			assert.Contains(t, output.String(), "request headers")
+			assert.Contains(t, output.String(), "response body")
+
+			for _, secret := range tt.secrets {
+				assert.NotContains(t, output.String(), secret)
+				assert.NotContains(t, client.ToString(), secret)
+			}
+		})
+	}
+}
+
+func TestRedactToString(t *testing.T) {
+	client := newMockClient(t, &ClientOptions{
+		Username: "admin",
+		Password: "basic-secret",
+		Headers:  map[string]string{"Authorization": "Bearer header-token", "X-Tenant": "acme"},
+		OAuthClientCredentials: &OAuthCredentials{
+			ClientID:     "client",
+			ClientSecret: "client-secret",
+		},
+	})
+
+	result := client.ToString()
+
+	assert.Contains(t, result, "username: admin")
+	assert.Contains(t, result, "password: (redacted)")
+	assert.Contains(t, result, "oauth_client_secret: (redacted)")
+	assert.Contains(t, result, "Authorization: (redacted)")
+	assert.Contains(t, result, "X-Tenant: acme")
+	assert.NotContains(t, result, "basic-secret")
+	assert.NotContains(t, result, "header-token")
+	assert.NotContains(t, result, "client-secret")
+}
@@ -0,0 +1,107 @@
+package restclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	// tracerName is the name of the tracer of all spans of the provider.
+	tracerName = "github.com/thegeeklab/terraform-provider-restapi"
+	// serviceName is the service name of the exported traces.
+	serviceName = "terraform-provider-restapi"
+	// tracingFlushTimeout bounds the export of the spans when a root span ends,
+	// so an unreachable collector does not stall the operations.
+	tracingFlushTimeout = 5 * time.Second
+)
+
+// TracingOptions configures the export of OpenTelemetry traces.
+type TracingOptions struct {
+	// Endpoint is the URL of the OTLP/HTTP collector, e.g. `http://localhost:4318`.
+	Endpoint string
+	// Headers are sent with the export requests, e.g. for authentication.
+	Headers map[string]string
+}
+
+// newTracer returns the tracer exporting the spans to the configured OTLP/HTTP
+// endpoint, and its provider to flush the spans. Returns a no-op tracer without
+// provider if tracing is not configured.
+func newTracer(ctx context.Context, opts *TracingOptions) (trace.Tracer, *sdktrace.TracerProvider, error) {
+	if opts == nil || opts.Endpoint == "" {
+		return noop.NewTracerProvider().Tracer(tracerName), nil, nil
+	}
+
+	endpoint, err := url.Parse(opts.Endpoint)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: tracing endpoint: %w", ErrInvalidClientOptions, err)
+	}
+
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return nil, nil, fmt.Errorf("%w: tracing endpoint: unsupported scheme '%s'",
+			ErrInvalidClientOptions, endpoint.Scheme)
+	}
+
+	exporter, err := otlptracehttp.New(ctx,
+		otlptracehttp.WithEndpointURL(opts.Endpoint),
+		otlptracehttp.WithHeaders(opts.Headers),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: tracing endpoint: %w", ErrInvalidClientOptions, err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+
+	return provider.Tracer(tracerName), provider, nil
+}
+
+// StartSpan starts a span of an operation like a CRUD operation of an object.
+// The spans of all requests sent with the returned context are child spans of
+// the operation span.
+func (rc *RestClient) StartSpan(
+	ctx context.Context, name string, attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	return rc.tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan ends the span and records the error, if any. The recorded spans are
+// exported when a root span ends, as the provider process might be stopped by
+// Terraform at any time. The export is aborted after the flush timeout, the
+// remaining spans are exported in the background with the next batch.
+func (rc *RestClient) EndSpan(ctx context.Context, span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+
+	if rc.tracerProvider == nil {
+		return
+	}
+
+	if s, ok := span.(sdktrace.ReadOnlySpan); ok && s.Parent().IsValid() {
+		return
+	}
+
+	flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), rc.flushTimeout)
+	defer cancel()
+
+	if err := rc.tracerProvider.ForceFlush(flushCtx); err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("failed to export traces: %s", err.Error()))
+	}
+}
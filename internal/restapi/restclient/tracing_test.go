@@ -0,0 +1,121 @@
+package restclient
+
+import (
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+func TestTracing(t *testing.T) {
+	collector := testutils.NewTraceCollector(t)
+
+	client := newMockClient(t, &ClientOptions{
+		RateLimit: 100,
+		OAuthClientCredentials: &OAuthCredentials{
+			ClientID:      "client",
+			ClientSecret:  "secret",
+			TokenEndpoint: "https://restapi.local/token",
+		},
+		Tracing: &TracingOptions{Endpoint: collector.Endpoint},
+	})
+
+	httpmock.RegisterResponder(
+		http.MethodPost,
+		"https://restapi.local/token",
+		httpmock.NewStringResponder(http.StatusOK,
+			`{"access_token":"token","token_type":"bearer","expires_in":3600}`).
+			HeaderSet(http.Header{"Content-Type": {"application/json"}}),
+	)
+
+	ctx, span := client.StartSpan(t.Context(), "operation")
+	traceID := span.SpanContext().TraceID().String()
+
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://restapi.local/things",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Contains(t, req.Header.Get("Traceparent"), traceID)
+
+			return httpmock.NewStringResponse(http.StatusNotFound, ""), nil
+		},
+	)
+
+	_, err := client.Do(ctx, http.MethodGet, "/things", "", "")
+	client.EndSpan(ctx, span, err)
+
+	spans := collector.Spans()
+	spanID := func(name string) string {
+		return hex.EncodeToString(spans[name].GetSpanId())
+	}
+
+	if assert.Len(t, spans, 4) {
+		assert.Equal(t, traceID, hex.EncodeToString(spans["operation"].GetTraceId()))
+		assert.Empty(t, spans["operation"].GetParentSpanId())
+		assert.Equal(t, spanID("operation"), hex.EncodeToString(spans["GET"].GetParentSpanId()))
+		assert.Equal(t, spanID("GET"), hex.EncodeToString(spans["oauth2.token"].GetParentSpanId()))
+		assert.Equal(t, spanID("GET"), hex.EncodeToString(spans["rate_limit.wait"].GetParentSpanId()))
+		assert.Equal(t, tracepb.Status_STATUS_CODE_ERROR, spans["GET"].GetStatus().GetCode())
+	}
+}
+
+func TestTracingDisabled(t *testing.T) {
+	client := newMockClient(t, &ClientOptions{})
+
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://restapi.local/things",
+		func(req *http.Request) (*http.Response, error) {
+			assert.Empty(t, req.Header.Get("Traceparent"))
+
+			return httpmock.NewStringResponse(http.StatusOK, ""), nil
+		},
+	)
+
+	ctx, span := client.StartSpan(t.Context(), "operation")
+	assert.Equal(t, trace.SpanContext{}, span.SpanContext())
+
+	_, err := client.Do(ctx, http.MethodGet, "/things", "", "")
+	client.EndSpan(ctx, span, err)
+
+	assert.NoError(t, err)
+}
+
+func TestTracingInvalidEndpoint(t *testing.T) {
+	_, err := New(t.Context(), &ClientOptions{
+		Endpoint: "https://restapi.local/",
+		Tracing:  &TracingOptions{Endpoint: "localhost:4318"},
+	})
+
+	assert.ErrorIs(t, err, ErrInvalidClientOptions)
+}
+
+func TestTracingFlushTimeout(t *testing.T) {
+	release := make(chan struct{})
+
+	collector := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		<-release
+	}))
+	t.Cleanup(collector.Close)
+	t.Cleanup(func() { close(release) })
+
+	client := newMockClient(t, &ClientOptions{
+		Tracing: &TracingOptions{Endpoint: collector.URL},
+	})
+	client.flushTimeout = 100 * time.Millisecond
+
+	ctx, span := client.StartSpan(t.Context(), "operation")
+
+	start := time.Now()
+	client.EndSpan(ctx, span, nil)
+
+	assert.Less(t, time.Since(start), time.Second)
+}
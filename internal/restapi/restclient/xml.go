@@ -0,0 +1,211 @@
+package restclient
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+const (
+	// DefaultXMLRoot is the name of the root element of encoded XML documents.
+	DefaultXMLRoot = "root"
+
+	// xmlAttributePrefix marks keys that are mapped to XML attributes.
+	xmlAttributePrefix = "@"
+	// xmlTextKey holds the text content of elements that also have attributes or child elements.
+	xmlTextKey = "#text"
+)
+
+// XMLCodec encodes and decodes XML documents. Documents are mapped to objects
+// without their root element: attributes are mapped to keys prefixed with `@`,
+// repeated elements to arrays and the text content of elements with attributes
+// or child elements to the key `#text`. Elements without attributes and child
+// elements are mapped to their text content. The same mapping is used for
+// encoding, with Root as name of the root element.
+type XMLCodec struct {
+	Root string
+}
+
+func (c *XMLCodec) MediaTypes() []string {
+	return []string{ContentTypeXML, "text/xml"}
+}
+
+func (c *XMLCodec) Encode(data map[string]any) (string, string, error) {
+	var buffer bytes.Buffer
+
+	root := c.Root
+	if root == "" {
+		root = DefaultXMLRoot
+	}
+
+	buffer.WriteString(xml.Header)
+
+	encoder := xml.NewEncoder(&buffer)
+
+	if err := encodeXMLElement(encoder, root, data); err != nil {
+		return "", "", fmt.Errorf("%w: %w", ErrRequestFormat, err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return "", "", fmt.Errorf("%w: %w", ErrRequestFormat, err)
+	}
+
+	return buffer.String(), ContentTypeXML, nil
+}
+
+func (c *XMLCodec) Decode(body string) (any, error) {
+	decoder := xml.NewDecoder(strings.NewReader(body))
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: no xml root element", ErrResponseFormat)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrResponseFormat, err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		value, err := decodeXMLElement(decoder, start)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrResponseFormat, err)
+		}
+
+		if obj, ok := value.(map[string]any); ok {
+			return obj, nil
+		}
+
+		if text, _ := value.(string); text != "" {
+			return map[string]any{xmlTextKey: text}, nil
+		}
+
+		return map[string]any{}, nil
+	}
+}
+
+func encodeXMLElement(encoder *xml.Encoder, name string, value any) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+
+	switch v := value.(type) {
+	case []any:
+		for _, item := range v {
+			if err := encodeXMLElement(encoder, name, item); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case map[string]any:
+		keys := slices.Sorted(maps.Keys(v))
+
+		for _, key := range keys {
+			if attr, ok := strings.CutPrefix(key, xmlAttributePrefix); ok {
+				start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: attr}, Value: formatValue(v[key])})
+			}
+		}
+
+		if err := encoder.EncodeToken(start); err != nil {
+			return err
+		}
+
+		if text, ok := v[xmlTextKey]; ok {
+			if err := encoder.EncodeToken(xml.CharData(formatValue(text))); err != nil {
+				return err
+			}
+		}
+
+		for _, key := range keys {
+			if key == xmlTextKey || strings.HasPrefix(key, xmlAttributePrefix) {
+				continue
+			}
+
+			if err := encodeXMLElement(encoder, key, v[key]); err != nil {
+				return err
+			}
+		}
+	default:
+		if err := encoder.EncodeToken(start); err != nil {
+			return err
+		}
+
+		if err := encoder.EncodeToken(xml.CharData(formatValue(v))); err != nil {
+			return err
+		}
+	}
+
+	return encoder.EncodeToken(start.End())
+}
+
+func decodeXMLElement(decoder *xml.Decoder, start xml.StartElement) (any, error) {
+	var text strings.Builder
+
+	obj := make(map[string]any)
+
+	for _, attr := range start.Attr {
+		// Namespace declarations are not part of the data.
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+			continue
+		}
+
+		obj[xmlAttributePrefix+attr.Name.Local] = attr.Value
+	}
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			child, err := decodeXMLElement(decoder, t)
+			if err != nil {
+				return nil, err
+			}
+
+			addXMLChild(obj, t.Name.Local, child)
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			content := strings.TrimSpace(text.String())
+
+			if len(obj) == 0 {
+				return content, nil
+			}
+
+			if content != "" {
+				obj[xmlTextKey] = content
+			}
+
+			return obj, nil
+		}
+	}
+}
+
+// addXMLChild adds the child element to the object. Repeated elements are collected in an array.
+func addXMLChild(obj map[string]any, name string, child any) {
+	existing, ok := obj[name]
+	if !ok {
+		obj[name] = child
+
+		return
+	}
+
+	if items, ok := existing.([]any); ok {
+		obj[name] = append(items, child)
+
+		return
+	}
+
+	obj[name] = []any{existing, child}
+}
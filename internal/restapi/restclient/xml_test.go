@@ -0,0 +1,106 @@
+package restclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeXML(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    any
+		wantErr error
+	}{
+		{
+			name: "elements",
+			data: `<?xml version="1.0"?><user><id>1</id><name>foo</name></user>`,
+			want: map[string]any{"id": "1", "name": "foo"},
+		},
+		{
+			name: "attributes",
+			data: `<user id="1" xmlns="urn:users"><name lang="en">foo</name></user>`,
+			want: map[string]any{"@id": "1", "name": map[string]any{"@lang": "en", "#text": "foo"}},
+		},
+		{
+			name: "repeated elements",
+			data: `<users>
+				<user><id>1</id></user>
+				<user><id>2</id></user>
+				<count>2</count>
+			</users>`,
+			want: map[string]any{
+				"user":  []any{map[string]any{"id": "1"}, map[string]any{"id": "2"}},
+				"count": "2",
+			},
+		},
+		{
+			name: "empty elements",
+			data: `<user><name/><groups></groups></user>`,
+			want: map[string]any{"name": "", "groups": ""},
+		},
+		{
+			name: "empty root",
+			data: `<user/>`,
+			want: map[string]any{},
+		},
+		{
+			name:    "no root",
+			data:    ``,
+			wantErr: ErrResponseFormat,
+		},
+		{
+			name:    "invalid",
+			data:    `<user><id>1</user>`,
+			wantErr: ErrResponseFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&XMLCodec{}).Decode(tt.data)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEncodeXML(t *testing.T) {
+	data := map[string]any{
+		"@id":     "1",
+		"name":    map[string]any{"@lang": "en", "#text": "foo & bar"},
+		"enabled": true,
+		"size":    float64(10),
+		"groups":  []any{"admin", "dev"},
+		"owner":   map[string]any{"id": "2"},
+	}
+
+	got, contentType, err := (&XMLCodec{Root: "user"}).Encode(data)
+	assert.NoError(t, err)
+	assert.Equal(t, ContentTypeXML, contentType)
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+		`<user id="1"><enabled>true</enabled><groups>admin</groups><groups>dev</groups>`+
+		`<name lang="en">foo &amp; bar</name><owner><id>2</id></owner><size>10</size></user>`, got)
+
+	decoded, err := (&XMLCodec{}).Decode(got)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"@id":     "1",
+		"name":    map[string]any{"@lang": "en", "#text": "foo & bar"},
+		"enabled": "true",
+		"size":    "10",
+		"groups":  []any{"admin", "dev"},
+		"owner":   map[string]any{"id": "2"},
+	}, decoded)
+
+	got, _, err = (&XMLCodec{}).Encode(map[string]any{"id": "1"})
+	assert.NoError(t, err)
+	assert.Contains(t, got, "<root><id>1</id></root>")
+}
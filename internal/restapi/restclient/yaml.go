@@ -0,0 +1,83 @@
+package restclient
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAMLCodec encodes and decodes YAML documents. Mappings are decoded to maps
+// with string keys, so the result can be handled like decoded JSON data.
+type YAMLCodec struct{}
+
+func (c *YAMLCodec) MediaTypes() []string {
+	return []string{ContentTypeYAML, "application/x-yaml", "text/yaml", "text/x-yaml"}
+}
+
+func (c *YAMLCodec) Encode(data map[string]any) (string, string, error) {
+	b, err := yaml.Marshal(data)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %w", ErrRequestFormat, err)
+	}
+
+	return string(b), ContentTypeYAML, nil
+}
+
+func (c *YAMLCodec) Decode(body string) (any, error) {
+	var (
+		node   yaml.Node
+		result any
+	)
+
+	if err := yaml.Unmarshal([]byte(body), &node); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrResponseFormat, err)
+	}
+
+	keepTimestamps(&node)
+
+	if err := node.Decode(&result); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrResponseFormat, err)
+	}
+
+	return normalizeYAML(result), nil
+}
+
+// keepTimestamps tags the unquoted dates and timestamps of the document as
+// strings, so they keep their source text instead of being reformatted.
+func keepTimestamps(node *yaml.Node) {
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!timestamp" {
+		node.Tag = "!!str"
+	}
+
+	for _, child := range node.Content {
+		keepTimestamps(child)
+	}
+}
+
+// normalizeYAML converts mappings with non-string keys, which can not be encoded as JSON.
+func normalizeYAML(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = normalizeYAML(item)
+		}
+
+		return v
+	case map[any]any:
+		result := make(map[string]any, len(v))
+
+		for key, item := range v {
+			result[fmt.Sprintf("%v", key)] = normalizeYAML(item)
+		}
+
+		return result
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeYAML(item)
+		}
+
+		return v
+	}
+
+	return value
+}
@@ -0,0 +1,73 @@
+package restclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    any
+		wantErr error
+	}{
+		{
+			name: "mapping",
+			data: "id: 1\nname: foo\ntags:\n  - a\n  - b\nowner:\n  name: bar\n",
+			want: map[string]any{
+				"id":    1,
+				"name":  "foo",
+				"tags":  []any{"a", "b"},
+				"owner": map[string]any{"name": "bar"},
+			},
+		},
+		{
+			name: "non-string keys",
+			data: "ports:\n  80: http\n  443: https\n",
+			want: map[string]any{"ports": map[string]any{"80": "http", "443": "https"}},
+		},
+		{
+			name: "timestamps",
+			data: "date: 2001-12-14\ntime: 2001-12-14t21:59:43.10-05:00\ntagged: !!timestamp 2001-12-15\n",
+			want: map[string]any{
+				"date":   "2001-12-14",
+				"time":   "2001-12-14t21:59:43.10-05:00",
+				"tagged": "2001-12-15",
+			},
+		},
+		{
+			name: "sequence",
+			data: "- id: 1\n- id: 2\n",
+			want: []any{map[string]any{"id": 1}, map[string]any{"id": 2}},
+		},
+		{
+			name:    "invalid",
+			data:    "id: [1",
+			wantErr: ErrResponseFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := (&YAMLCodec{}).Decode(tt.data)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEncodeYAML(t *testing.T) {
+	got, contentType, err := (&YAMLCodec{}).Encode(map[string]any{"name": "foo", "size": float64(1), "tags": []any{"a"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, ContentTypeYAML, contentType)
+	assert.Equal(t, "name: foo\nsize: 1\ntags:\n    - a\n", got)
+}
@@ -6,8 +6,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
-
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -16,7 +14,10 @@ var ErrCreateObject = errors.New("failed to create object")
 // Create uses the RestObject's client to send a POST request to create the
 // object on the remote API. It handles setting the object's ID from the
 // response and syncing the object's state.
-func (ro *RestObject) Create(ctx context.Context) error {
+func (ro *RestObject) Create(ctx context.Context) (err error) {
+	ctx, span := ro.startSpan(ctx, "create")
+	defer func() { ro.endSpan(ctx, span, err) }()
+
 	opts := ro.Options
 
 	// Failsafe: The constructor should prevent this situation, but protect here also.
@@ -27,21 +28,25 @@ func (ro *RestObject) Create(ctx context.Context) error {
 		return fmt.Errorf("%w: %s", ErrCreateObject, "no id and client not configured to read response")
 	}
 
-	data, err := utils.GetRequestData(opts.Data, nil)
+	data, contentType, err := ro.encodeRequestData(ro.withSecretData(opts.Data), nil, opts.RequestFiles)
 	if err != nil {
 		return err
 	}
 
 	postPath := opts.PostPath
 
-	if opts.QueryString != "" {
-		tflog.Debug(ctx, fmt.Sprintf("add query string '%s'", opts.QueryString))
+	// There is no API response to resolve response placeholders from before the object exists.
+	if strings.Contains(postPath, "{response.") {
+		return fmt.Errorf("%w: placeholder '{response.<key>}' not supported in create_path '%s'",
+			ErrCreateObject, postPath)
+	}
 
-		postPath = fmt.Sprintf("%s?%s", opts.PostPath, opts.QueryString)
+	postPath, err = ro.requestPath(ctx, postPath)
+	if err != nil {
+		return err
 	}
 
-	resultString, _, err := ro.client.SendRequest(
-		ctx, opts.CreateMethod, strings.ReplaceAll(postPath, "{id}", opts.ID), data)
+	resp, err := ro.do(ctx, opts.CreateMethod, postPath, data, contentType)
 	if err != nil {
 		return err
 	}
@@ -52,7 +57,10 @@ func (ro *RestObject) Create(ctx context.Context) error {
 			ro.client.Options.WriteReturnsObject, ro.client.Options.CreateReturnsObject,
 		))
 
-		err = ro.setData(ctx, resultString)
+		err = ro.setData(ctx, resp)
+		if err != nil {
+			return err
+		}
 
 		// Yet another failsafe. In case something terrible went wrong internally,
 		// bail out so the user at least knows that the ID did not get set.
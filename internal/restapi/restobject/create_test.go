@@ -2,17 +2,22 @@ package restobject
 
 import (
 	"fmt"
+	"io"
+	"maps"
 	"net/http"
 	"testing"
 
 	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils/mockclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
 
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestCreate(t *testing.T) {
-	client := newMockClient(t, &restclient.ClientOptions{CreateReturnsObject: true})
+	client := mockclient.New(t, &restclient.ClientOptions{CreateReturnsObject: true})
 
 	tests := []struct {
 		name    string
@@ -56,3 +61,288 @@ func TestCreate(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateRequestFormat(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            *ObjectOptions
+		wantBody        string
+		wantContentType string
+		wantErr         error
+	}{
+		{
+			name:            "json",
+			opts:            &ObjectOptions{},
+			wantBody:        `{"id":"1","name":"foo"}`,
+			wantContentType: restclient.ContentTypeJSON,
+		},
+		{
+			name:            "form",
+			opts:            &ObjectOptions{RequestFormat: restclient.FormatForm},
+			wantBody:        "id=1&name=foo",
+			wantContentType: restclient.ContentTypeForm,
+		},
+		{
+			name:            "form envelope",
+			opts:            &ObjectOptions{RequestFormat: restclient.FormatForm, RequestEnvelope: "thing"},
+			wantBody:        "thing%5Bid%5D=1&thing%5Bname%5D=foo",
+			wantContentType: restclient.ContentTypeForm,
+		},
+		{
+			name:    "invalid format",
+			opts:    &ObjectOptions{RequestFormat: "invalid"},
+			wantErr: ErrInvalidObjectOptions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := mockclient.New(t, &restclient.ClientOptions{CreateReturnsObject: true})
+
+			httpmock.RegisterResponder(
+				client.Options.CreateMethod,
+				"https://restapi.local/things",
+				func(req *http.Request) (*http.Response, error) {
+					body, _ := io.ReadAll(req.Body)
+
+					assert.Equal(t, tt.wantContentType, req.Header.Get("Content-Type"))
+					assert.Equal(t, tt.wantBody, string(body))
+
+					return httpmock.NewStringResponse(http.StatusOK, `{"id": "1", "name": "foo"}`), nil
+				},
+			)
+
+			tt.opts.Path = "/things"
+			tt.opts.Data = APIPayload{"id": "1", "name": "foo"}
+
+			ro, err := New(client, tt.opts)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, ro.Create(t.Context()))
+		})
+	}
+}
+
+func TestCreateXML(t *testing.T) {
+	client := mockclient.New(t, &restclient.ClientOptions{
+		RequestFormat:      restclient.FormatXML,
+		ResponseFormat:     restclient.FormatXML,
+		XMLRoot:            "user",
+		WriteReturnsObject: true,
+		DriftDetection:     true,
+	})
+
+	httpmock.RegisterResponder(
+		client.Options.CreateMethod,
+		"https://restapi.local/users",
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+
+			assert.Equal(t, restclient.ContentTypeXML, req.Header.Get("Content-Type"))
+			assert.Contains(t, string(body), `<user><groups>admin</groups><name>foo</name></user>`)
+
+			return httpmock.NewStringResponse(http.StatusCreated,
+				`<user id="1"><name>bar</name><groups>admin</groups><created>today</created></user>`), nil
+		},
+	)
+
+	ro, _ := New(client, &ObjectOptions{
+		Path:        "/users",
+		IDAttribute: "@id",
+		Data:        APIPayload{"name": "foo", "groups": "admin"},
+	})
+
+	err := ro.Create(t.Context())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", ro.Options.ID)
+	assert.EqualValues(t, APIPayload{"name": "bar", "groups": "admin"}, ro.Options.Data)
+	assert.EqualValues(t, APIResponse{"@id": "1", "name": "bar", "groups": "admin", "created": "today"},
+		ro.Options.APIResponse)
+}
+
+func TestCreateXMLDrift(t *testing.T) {
+	client := mockclient.New(t, &restclient.ClientOptions{
+		RequestFormat:      restclient.FormatXML,
+		ResponseFormat:     restclient.FormatXML,
+		WriteReturnsObject: true,
+		DriftDetection:     true,
+	})
+
+	// Return the created object as sent, like most APIs do.
+	httpmock.RegisterResponder(
+		client.Options.CreateMethod,
+		"https://restapi.local/things",
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+
+			return httpmock.NewStringResponse(http.StatusCreated, string(body)), nil
+		},
+	)
+
+	data := APIPayload{
+		"id":      "1",
+		"count":   float64(10),
+		"size":    float64(1000000),
+		"ratio":   float64(0.5),
+		"enabled": true,
+		"tags":    []any{float64(1), float64(2)},
+		"meta":    map[string]any{"revision": float64(3)},
+		"name":    "foo",
+	}
+
+	ro, _ := New(client, &ObjectOptions{Path: "/things", Data: maps.Clone(data)})
+
+	err := ro.Create(t.Context())
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, data, ro.Options.Data)
+	assert.Equal(t, "10", ro.Options.APIResponse["count"])
+}
+
+func TestCreateYAML(t *testing.T) {
+	client := mockclient.New(t, &restclient.ClientOptions{
+		RequestFormat:      restclient.FormatYAML,
+		WriteReturnsObject: true,
+	})
+
+	httpmock.RegisterResponder(
+		client.Options.CreateMethod,
+		"https://restapi.local/configs",
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+
+			assert.Equal(t, restclient.ContentTypeYAML, req.Header.Get("Content-Type"))
+			assert.Equal(t, "name: foo\nreplicas: 2\n", string(body))
+
+			resp := httpmock.NewStringResponse(http.StatusCreated, "id: 1\nname: foo\nreplicas: 2\n")
+			resp.Header.Set("Content-Type", "application/x-yaml")
+
+			return resp, nil
+		},
+	)
+
+	ro, _ := New(client, &ObjectOptions{
+		Path: "/configs",
+		Data: APIPayload{"name": "foo", "replicas": float64(2)},
+	})
+
+	err := ro.Create(t.Context())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", ro.Options.ID)
+	assert.EqualValues(t, APIResponse{"id": float64(1), "name": "foo", "replicas": float64(2)}, ro.Options.APIResponse)
+}
+
+func TestCreateSecretData(t *testing.T) {
+	client := mockclient.New(t, &restclient.ClientOptions{
+		WriteReturnsObject: true,
+		DriftDetection:     true,
+	})
+
+	httpmock.RegisterResponder(
+		client.Options.CreateMethod,
+		"https://restapi.local/users",
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+
+			assert.JSONEq(t, `{"name":"foo","auth":{"user":"foo","password":"secret"},"token":"secret"}`,
+				string(body))
+
+			return httpmock.NewStringResponse(http.StatusCreated,
+				`{"id":"1","name":"bar","auth":{"user":"foo","password":"secret"},"token":"secret"}`), nil
+		},
+	)
+
+	ro, _ := New(client, &ObjectOptions{
+		Path:       "/users",
+		Data:       APIPayload{"name": "foo", "auth": map[string]any{"user": "foo"}},
+		SecretData: APIPayload{"auth": map[string]any{"password": "secret"}, "token": "secret"},
+	})
+
+	err := ro.Create(t.Context())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", ro.Options.ID)
+	assert.EqualValues(t, APIPayload{"name": "bar", "auth": map[string]any{"user": "foo"}}, ro.Options.Data)
+	assert.EqualValues(t, APIResponse{"id": "1", "name": "bar", "auth": map[string]any{"user": "foo"}},
+		ro.Options.APIResponse)
+	assert.NotContains(t, ro.Options.APIResponseRaw, "secret")
+}
+
+func TestCreateSensitiveKeys(t *testing.T) {
+	client := mockclient.New(t, &restclient.ClientOptions{
+		WriteReturnsObject: true,
+		DriftDetection:     true,
+	})
+
+	httpmock.RegisterResponder(
+		client.Options.CreateMethod,
+		"https://restapi.local/users",
+		httpmock.NewStringResponder(http.StatusCreated,
+			`{"id":"1","name":"foo","token":"secret","keys":[{"id":"a","key":"secret"}]}`),
+	)
+
+	ro, _ := New(client, &ObjectOptions{
+		Path:          "/users",
+		Data:          APIPayload{"name": "foo", "token": "secret"},
+		SensitiveKeys: []string{"token", "keys/*/key"},
+	})
+
+	err := ro.Create(t.Context())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", ro.Options.ID)
+	assert.EqualValues(t, APIPayload{"name": "foo", "token": "secret"}, ro.Options.Data)
+	assert.EqualValues(t, APIResponse{
+		"id": "1", "name": "foo", "token": utils.RedactedValue,
+		"keys": []any{map[string]any{"id": "a", "key": utils.RedactedValue}},
+	}, ro.Options.APIResponse)
+	assert.EqualValues(t, APIResponse{"token": "secret", "keys/0/key": "secret"}, ro.Options.SensitiveValues)
+	assert.NotContains(t, ro.Options.APIResponseRaw, "secret")
+	assert.NotContains(t, ro.Options.CreateResponseRaw, "secret")
+}
+
+func TestSensitiveKeysEnvelope(t *testing.T) {
+	client := mockclient.New(t, &restclient.ClientOptions{})
+
+	ro, _ := New(client, &ObjectOptions{
+		Path:            "/users",
+		RequestEnvelope: "user",
+		ResponseKey:     "data/user",
+		SensitiveKeys:   []string{"token"},
+	})
+
+	assert.Equal(t, []string{"token", "user/token", "data/user/token", "items/*/token"}, ro.sensitiveKeys("items"))
+}
+
+func TestCreateTracing(t *testing.T) {
+	client, collector := mockclient.NewTraced(t, &restclient.ClientOptions{WriteReturnsObject: true})
+
+	httpmock.RegisterResponder(
+		client.Options.CreateMethod,
+		"https://restapi.local/users",
+		httpmock.NewStringResponder(http.StatusCreated, `{"id":"1","name":"foo"}`),
+	)
+
+	ro, _ := New(client, &ObjectOptions{Path: "/users", Data: APIPayload{"name": "foo"}})
+
+	err := ro.Create(t.Context())
+	assert.NoError(t, err)
+
+	spans := collector.Spans()
+
+	if testutils.AssertChildSpan(t, spans, "restobject.create", client.Options.CreateMethod) {
+		attrs := make(map[string]string)
+
+		for _, attr := range spans["restobject.create"].GetAttributes() {
+			attrs[attr.GetKey()] = attr.GetValue().GetStringValue()
+		}
+
+		assert.Equal(t, map[string]string{"restapi.object.path": "/users", "restapi.object.id": "1"}, attrs)
+	}
+}
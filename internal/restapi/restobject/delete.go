@@ -2,19 +2,16 @@ package restobject
 
 import (
 	"context"
-	"fmt"
 	"net/http"
-	"strings"
-
-	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Delete deletes the RestObject from the API by sending a DELETE request.
 // It returns an error if the delete request fails.
-func (ro *RestObject) Delete(ctx context.Context) error {
-	var err error
+func (ro *RestObject) Delete(ctx context.Context) (err error) {
+	ctx, span := ro.startSpan(ctx, "delete")
+	defer func() { ro.endSpan(ctx, span, err) }()
 
 	opts := ro.Options
 
@@ -24,22 +21,18 @@ func (ro *RestObject) Delete(ctx context.Context) error {
 		return nil
 	}
 
-	deletePath := opts.DeletePath
-
-	if opts.QueryString != "" {
-		tflog.Debug(ctx, fmt.Sprintf("add query string '%s'", opts.QueryString))
-
-		deletePath = fmt.Sprintf("%s?%s", opts.DeletePath, opts.QueryString)
+	data, contentType, err := ro.encodeRequestData(opts.DestroyData, nil, nil)
+	if err != nil {
+		return err
 	}
 
-	data, err := utils.GetRequestData(opts.DestroyData, nil)
+	deletePath, err := ro.requestPath(ctx, opts.DeletePath)
 	if err != nil {
 		return err
 	}
 
-	_, status, err := ro.client.SendRequest(
-		ctx, opts.DeleteMethod, strings.ReplaceAll(deletePath, "{id}", opts.ID), data)
-	if err != nil && status != http.StatusNotFound && status != http.StatusGone {
+	resp, err := ro.do(ctx, opts.DeleteMethod, deletePath, data, contentType)
+	if err != nil && resp.StatusCode != http.StatusNotFound && resp.StatusCode != http.StatusGone {
 		return err
 	}
 
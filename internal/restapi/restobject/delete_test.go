@@ -7,13 +7,14 @@ import (
 
 	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
 	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils/mockclient"
 
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestDelete(t *testing.T) {
-	client := newMockClient(t, &restclient.ClientOptions{})
+	client := mockclient.New(t, &restclient.ClientOptions{})
 
 	tests := []struct {
 		name       string
@@ -0,0 +1,150 @@
+package restobject
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+)
+
+// jsonAPIKey is the top-level key of JSON:API documents holding the primary data.
+const jsonAPIKey = "data"
+
+var ErrResponseEnvelope = errors.New("failed to unwrap response envelope")
+
+// requestEnvelope returns the key path used to wrap request data.
+func (ro *RestObject) requestEnvelope() string {
+	if ro.Options.RequestEnvelope == "" && ro.Options.isJSONAPI() {
+		return jsonAPIKey
+	}
+
+	return ro.Options.RequestEnvelope
+}
+
+// responseKey returns the key path of the object in response data.
+func (ro *RestObject) responseKey() string {
+	if ro.Options.ResponseKey == "" && ro.Options.isJSONAPI() {
+		return jsonAPIKey
+	}
+
+	return ro.Options.ResponseKey
+}
+
+// wrapRequestData wraps the given data into the configured request envelope.
+// In JSON:API mode, the data is converted to a JSON:API resource object first.
+// Returns nil if data is nil.
+func (ro *RestObject) wrapRequestData(data APIPayload) map[string]any {
+	if data == nil {
+		return nil
+	}
+
+	result := map[string]any(data)
+
+	if ro.Options.isJSONAPI() {
+		result = toJSONAPIResource(result)
+	}
+
+	envelope := utils.SanitizePath(ro.requestEnvelope())
+	if envelope == "" {
+		return result
+	}
+
+	parts := strings.Split(envelope, "/")
+	for i := len(parts) - 1; i >= 0; i-- {
+		result = map[string]any{parts[i]: result}
+	}
+
+	return result
+}
+
+// unwrapResponse extracts the object from the configured response envelope.
+// In JSON:API mode, the resource object is flattened by merging its `attributes`
+// with `id`, `type` and `relationships`. Returns the unmodified response
+// if no envelope is configured.
+func (ro *RestObject) unwrapResponse(state string) (string, error) {
+	key := ro.responseKey()
+
+	if key == "" && !ro.Options.isJSONAPI() {
+		return state, nil
+	}
+
+	obj := make(map[string]any)
+
+	if err := json.Unmarshal([]byte(state), &obj); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrResponseEnvelope, err)
+	}
+
+	if key != "" {
+		tmp, err := utils.GetObjectAtKey(obj, key)
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", ErrResponseEnvelope, err)
+		}
+
+		var ok bool
+
+		if obj, ok = tmp.(map[string]any); !ok {
+			return "", fmt.Errorf("%w: response_key '%s': data not a map but '%T'",
+				ErrResponseEnvelope, key, tmp)
+		}
+	}
+
+	if ro.Options.isJSONAPI() {
+		obj = fromJSONAPIResource(obj)
+	}
+
+	result, err := json.Marshal(obj)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrResponseEnvelope, err)
+	}
+
+	return string(result), nil
+}
+
+// toJSONAPIResource converts flat object data to a JSON:API resource object.
+// The members `id`, `type` and `relationships` are kept and all other keys
+// are moved to `attributes`.
+func toJSONAPIResource(data map[string]any) map[string]any {
+	resource := make(map[string]any)
+	attributes := make(map[string]any)
+
+	maps.Copy(attributes, data)
+
+	for _, key := range jsonAPIMembers() {
+		if value, ok := data[key]; ok {
+			resource[key] = value
+
+			delete(attributes, key)
+		}
+	}
+
+	resource["attributes"] = attributes
+
+	return resource
+}
+
+// fromJSONAPIResource converts a JSON:API resource object to flat object data.
+// It is the inverse of toJSONAPIResource.
+func fromJSONAPIResource(resource map[string]any) map[string]any {
+	data := make(map[string]any)
+
+	if attributes, ok := resource["attributes"].(map[string]any); ok {
+		maps.Copy(data, attributes)
+	}
+
+	for _, key := range jsonAPIMembers() {
+		if value, ok := resource[key]; ok {
+			data[key] = value
+		}
+	}
+
+	return data
+}
+
+// jsonAPIMembers returns the members of a JSON:API resource object
+// that are not part of its `attributes`.
+func jsonAPIMembers() []string {
+	return []string{"id", "type", "relationships"}
+}
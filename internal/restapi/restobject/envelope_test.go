@@ -0,0 +1,148 @@
+package restobject
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils/mockclient"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvelope(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     *ObjectOptions
+		response string
+		wantBody string
+		want     APIResponse
+		wantErr  error
+	}{
+		{
+			name:     "no envelope",
+			opts:     &ObjectOptions{},
+			response: `{"id": "1", "thing": "potato"}`,
+			wantBody: `{"thing": "potato"}`,
+			want:     APIResponse{"id": "1", "thing": "potato"},
+		},
+		{
+			name:     "data envelope",
+			opts:     &ObjectOptions{RequestEnvelope: "data", ResponseKey: "data"},
+			response: `{"data": {"id": "1", "thing": "potato"}, "meta": {"version": 1}}`,
+			wantBody: `{"data": {"thing": "potato"}}`,
+			want:     APIResponse{"id": "1", "thing": "potato"},
+		},
+		{
+			name:     "nested envelope",
+			opts:     &ObjectOptions{RequestEnvelope: "request/item", ResponseKey: "result/item"},
+			response: `{"result": {"item": {"id": "1", "thing": "potato"}}}`,
+			wantBody: `{"request": {"item": {"thing": "potato"}}}`,
+			want:     APIResponse{"id": "1", "thing": "potato"},
+		},
+		{
+			name: "jsonapi",
+			opts: &ObjectOptions{JSONAPI: new(true)},
+			response: `{"data": {"id": "1", "type": "things", "attributes": {"thing": "potato"}},
+				"links": {"self": "/things/1"}}`,
+			wantBody: `{"data": {"type": "things", "attributes": {"thing": "potato"}}}`,
+			want:     APIResponse{"id": "1", "type": "things", "thing": "potato"},
+		},
+		{
+			name:     "missing response key",
+			opts:     &ObjectOptions{ResponseKey: "data"},
+			response: `{"id": "1", "thing": "potato"}`,
+			wantBody: `{"thing": "potato"}`,
+			wantErr:  ErrResponseEnvelope,
+		},
+		{
+			name:     "invalid response key",
+			opts:     &ObjectOptions{ResponseKey: "data"},
+			response: `{"data": ["potato"]}`,
+			wantBody: `{"thing": "potato"}`,
+			wantErr:  ErrResponseEnvelope,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := mockclient.New(t, &restclient.ClientOptions{CreateReturnsObject: true})
+
+			httpmock.RegisterResponder(
+				client.Options.CreateMethod,
+				"https://restapi.local/things",
+				func(req *http.Request) (*http.Response, error) {
+					body, _ := io.ReadAll(req.Body)
+
+					assert.JSONEq(t, tt.wantBody, string(body))
+
+					return httpmock.NewStringResponse(http.StatusOK, tt.response), nil
+				},
+			)
+
+			data := APIPayload{}
+			if tt.opts.isJSONAPI() {
+				data["type"] = "things"
+			}
+
+			data["thing"] = "potato"
+
+			tt.opts.Path = "/things"
+			tt.opts.Data = data
+
+			ro, _ := New(client, tt.opts)
+
+			err := ro.Create(t.Context())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "1", ro.Options.ID)
+			assert.EqualValues(t, tt.want, ro.Options.APIResponse)
+		})
+	}
+}
+
+func TestEnvelopeProviderDefaults(t *testing.T) {
+	client := mockclient.New(t, &restclient.ClientOptions{RequestEnvelope: "data", ResponseKey: "data", JSONAPI: true})
+
+	ro, _ := New(client, &ObjectOptions{Path: "/things", ResponseKey: "result"})
+
+	assert.Equal(t, "data", ro.Options.RequestEnvelope)
+	assert.Equal(t, "result", ro.Options.ResponseKey)
+	assert.True(t, ro.Options.isJSONAPI())
+
+	ro, _ = New(client, &ObjectOptions{Path: "/things", JSONAPI: new(false)})
+
+	assert.False(t, ro.Options.isJSONAPI())
+}
+
+func TestFindJSONAPI(t *testing.T) {
+	client := mockclient.New(t, &restclient.ClientOptions{JSONAPI: true})
+
+	response := map[string]any{
+		"data": []any{
+			map[string]any{"id": "1", "type": "things", "attributes": map[string]any{"thing": "potato"}},
+			map[string]any{"id": "2", "type": "things", "attributes": map[string]any{"thing": "fork"}},
+		},
+	}
+
+	httpmock.RegisterResponder(
+		client.Options.ReadMethod,
+		"https://restapi.local/things",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, response),
+	)
+
+	ro, _ := New(client, &ObjectOptions{Path: "/things"})
+
+	got, err := ro.Find(t.Context(), "", "thing", "fork", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2", ro.Options.ID)
+	assert.EqualValues(t, APIResponse{"id": "2", "type": "things", "thing": "fork"}, got)
+}
@@ -5,8 +5,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"reflect"
+	"strings"
 
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
 	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -19,9 +22,13 @@ var (
 
 // Find searches the REST API for an object matching the given search criteria.
 // It issues a GET request to the API path, optionally adding the queryString.
+// The placeholders `{search_key}` and `{search_value}` in the path and the queryString
+// are replaced by the URL-escaped search criteria to allow server-side filtering.
+// All other path placeholders are expanded as described in expandPath.
 // It parses the JSON response, extracting the result array at resultKey.
 // It loops through the array looking for an object where searchKey equals searchValue.
-// If found, it returns that object as the APIResponse.
+// If found, it returns that object as the APIResponse. In JSON:API mode, the
+// resultKey defaults to `data` and the resource objects are flattened.
 // It also extracts the ID attribute into the RestObject options.
 func (ro *RestObject) Find(
 	ctx context.Context, queryString, searchKey, searchValue, resultKey string,
@@ -34,17 +41,31 @@ func (ro *RestObject) Find(
 	)
 
 	opts := ro.Options
-	searchPath := opts.Path
+
+	searchPath, err := ro.expandPath(expandSearchPlaceholders(opts.Path, searchKey, searchValue, url.PathEscape))
+	if err != nil {
+		return resp, err
+	}
 
 	// Issue a GET to the base path and expect results to come back
 	if queryString != "" {
+		queryString = expandSearchPlaceholders(queryString, searchKey, searchValue, url.QueryEscape)
+
 		tflog.Debug(ctx, fmt.Sprintf("add query string '%s'", queryString))
 		searchPath = fmt.Sprintf("%s?%s", searchPath, queryString)
 	}
 
+	if resultKey == "" && opts.isJSONAPI() {
+		resultKey = jsonAPIKey
+	}
+
 	tflog.Debug(ctx, fmt.Sprintf("call api with path '%s'", searchPath))
 
-	resultString, _, err := ro.client.SendRequest(ctx, ro.client.Options.ReadMethod, searchPath, "")
+	searchResp, err := ro.client.DoRequest(ctx, &restclient.Request{
+		Method:        ro.client.Options.ReadMethod,
+		Path:          searchPath,
+		SensitiveKeys: ro.sensitiveKeys(resultKey),
+	})
 	if err != nil {
 		return resp, err
 	}
@@ -52,12 +73,17 @@ func (ro *RestObject) Find(
 	// Parse it seeking JSON data
 	tflog.Debug(ctx, "parse received response")
 
+	resultString, err := ro.client.Codecs.Decode(opts.ResponseFormat, searchResp)
+	if err != nil {
+		return resp, err
+	}
+
 	err = json.Unmarshal([]byte(resultString), &result)
 	if err != nil {
 		return resp, err
 	}
 
-	dataArray, err = getDataArray(result, resultKey)
+	dataArray, err = getDataArray(result, resultKey, ro.isXMLResponse(searchResp))
 	if err != nil {
 		return resp, err
 	}
@@ -70,7 +96,13 @@ func (ro *RestObject) Find(
 			return resp, fmt.Errorf("%w: data not a map of key value pairs", ErrFindResponse)
 		}
 
-		tflog.Debug(ctx, fmt.Sprintf("examining %v", hash))
+		if opts.isJSONAPI() {
+			hash = fromJSONAPIResource(hash)
+		}
+
+		masked, _ := utils.RedactKeys(hash, opts.SensitiveKeys)
+
+		tflog.Debug(ctx, fmt.Sprintf("examining %v", masked))
 		tflog.Debug(ctx, fmt.Sprintf("comparing '%s' to value of '%s'", searchValue, searchKey))
 
 		tmp, err := utils.GetStringAtKey(hash, searchKey)
@@ -83,10 +115,10 @@ func (ro *RestObject) Find(
 		if tmp == searchValue {
 			resp = hash
 
-			opts.ID, err = utils.GetStringAtKey(hash, opts.IDAttribute)
+			opts.ID, err = getObjectID(hash, opts)
 			if err != nil {
 				return resp, fmt.Errorf("%w: %w: no id_attribute '%s' in the record",
-					ErrFindResponse, err, opts.IDAttribute)
+					ErrFindResponse, err, idAttributeNames(opts))
 			}
 
 			tflog.Debug(ctx, fmt.Sprintf("found id '%s'", opts.ID))
@@ -94,7 +126,7 @@ func (ro *RestObject) Find(
 			// But there is no id attribute
 			if opts.ID == "" {
 				return resp, fmt.Errorf("%w: attribute '%s' not in object for '%s'='%s', or empty value",
-					ErrFindResponse, opts.IDAttribute, searchKey, searchValue)
+					ErrFindResponse, idAttributeNames(opts), searchKey, searchValue)
 			}
 
 			break
@@ -111,9 +143,11 @@ func (ro *RestObject) Find(
 
 // getDataArray extracts the data array from the find result.
 // If resultKey is specified, it looks for that key in the result map.
-// Otherwise, it expects the result to be a data array directly.
+// For XML responses, a single object at resultKey is treated as an array
+// with one element, as it can not be distinguished from a single repeated
+// element. Otherwise, it expects the result to be a data array directly.
 // Returns the data array and any error.
-func getDataArray(result any, resultKey string) ([]any, error) {
+func getDataArray(result any, resultKey string, xmlResponse bool) ([]any, error) {
 	var (
 		data []any
 		tmp  any
@@ -141,6 +175,10 @@ func getDataArray(result any, resultKey string) ([]any, error) {
 		return data, fmt.Errorf("%w: %w: result_key not found", ErrFindResponse, err)
 	}
 
+	if obj, ok := tmp.(map[string]any); ok && xmlResponse {
+		return []any{obj}, nil
+	}
+
 	if data, ok = tmp.([]any); !ok {
 		return data, fmt.Errorf("%w: result_key '%s': data not an array but '%T'",
 			ErrFindResponse, resultKey, tmp)
@@ -148,3 +186,21 @@ func getDataArray(result any, resultKey string) ([]any, error) {
 
 	return data, nil
 }
+
+// isXMLResponse reports whether the response is decoded as XML document.
+func (ro *RestObject) isXMLResponse(resp *restclient.Response) bool {
+	codec, err := ro.client.Codecs.Resolve(ro.Options.ResponseFormat, resp)
+	_, ok := codec.(*restclient.XMLCodec)
+
+	return err == nil && ok
+}
+
+// expandSearchPlaceholders replaces the placeholders `{search_key}` and `{search_value}`
+// in the given string by the search criteria. The escape function is applied to
+// the values to ensure they are safe for the respective part of the URL.
+func expandSearchPlaceholders(s, searchKey, searchValue string, escape func(string) string) string {
+	return strings.NewReplacer(
+		"{search_key}", escape(searchKey),
+		"{search_value}", escape(searchValue),
+	).Replace(s)
+}
@@ -6,13 +6,14 @@ import (
 	"testing"
 
 	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils/mockclient"
 
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestFind(t *testing.T) {
-	client := newMockClient(t, &restclient.ClientOptions{})
+	client := mockclient.New(t, &restclient.ClientOptions{})
 
 	tests := []struct {
 		name        string
@@ -58,3 +59,114 @@ func TestFind(t *testing.T) {
 		})
 	}
 }
+
+func TestFindPlaceholders(t *testing.T) {
+	client := mockclient.New(t, &restclient.ClientOptions{})
+
+	tests := []struct {
+		name        string
+		path        string
+		queryString string
+		searchValue string
+		wantURL     string
+	}{
+		{
+			name:        "query string",
+			path:        "/things",
+			queryString: "{search_key}={search_value}",
+			searchValue: "cat",
+			wantURL:     "https://restapi.local/things?thing=cat",
+		},
+		{
+			name:        "escaped query string",
+			path:        "/things",
+			queryString: "filter={search_value}&limit=1",
+			searchValue: "fat cat&co",
+			wantURL:     "https://restapi.local/things?filter=fat+cat%26co&limit=1",
+		},
+		{
+			name:        "escaped path",
+			path:        "/things/{search_value}/list",
+			searchValue: "fat cat/co",
+			wantURL:     "https://restapi.local/things/fat%20cat%2Fco/list",
+		},
+	}
+
+	for _, tt := range tests {
+		want := newTestObject(t, testObjectData["pet"])
+		want.Thing = tt.searchValue
+
+		httpmock.RegisterResponder(
+			client.Options.ReadMethod,
+			tt.wantURL,
+			httpmock.NewJsonResponderOrPanic(http.StatusOK, []testObject{want}),
+		)
+
+		t.Run(tt.name, func(t *testing.T) {
+			ro, _ := New(client, &ObjectOptions{Path: tt.path})
+
+			got, err := ro.Find(t.Context(), tt.queryString, "thing", tt.searchValue, "")
+
+			assert.NoError(t, err)
+			assert.EqualValues(t, want, mapToTestObject(t, got))
+		})
+	}
+}
+
+func TestFindXML(t *testing.T) {
+	client := mockclient.New(t, &restclient.ClientOptions{ResponseFormat: restclient.FormatXML})
+
+	tests := []struct {
+		name     string
+		response string
+		want     APIResponse
+	}{
+		{
+			name: "repeated elements",
+			response: `<users>
+				<user><id>1</id><name>foo</name></user>
+				<user><id>2</id><name>bar</name></user>
+			</users>`,
+			want: APIResponse{"id": "2", "name": "bar"},
+		},
+		{
+			name:     "single element",
+			response: `<users><user><id>2</id><name>bar</name></user></users>`,
+			want:     APIResponse{"id": "2", "name": "bar"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpmock.RegisterResponder(
+				client.Options.ReadMethod,
+				"https://restapi.local/users",
+				httpmock.NewStringResponder(http.StatusOK, tt.response),
+			)
+
+			ro, _ := New(client, &ObjectOptions{Path: "/users"})
+
+			got, err := ro.Find(t.Context(), "", "name", "bar", "user")
+
+			assert.NoError(t, err)
+			assert.Equal(t, "2", ro.Options.ID)
+			assert.EqualValues(t, tt.want, got)
+		})
+	}
+}
+
+func TestFindSingleObject(t *testing.T) {
+	client := mockclient.New(t, &restclient.ClientOptions{})
+
+	httpmock.RegisterResponder(
+		client.Options.ReadMethod,
+		"https://restapi.local/users",
+		httpmock.NewStringResponder(http.StatusOK, `{"user": {"id": "2", "name": "bar"}}`),
+	)
+
+	ro, _ := New(client, &ObjectOptions{Path: "/users"})
+
+	_, err := ro.Find(t.Context(), "", "name", "bar", "user")
+
+	assert.ErrorIs(t, err, ErrFindResponse)
+}
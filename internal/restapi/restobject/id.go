@@ -0,0 +1,74 @@
+package restobject
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+)
+
+// DefaultIDSeparator is used to join the components of composite IDs.
+const DefaultIDSeparator = ":"
+
+var ErrInvalidObjectID = errors.New("invalid object id")
+
+// getObjectID returns the object ID from the given data. For composite IDs,
+// the values of all id_attributes are joined by the id_separator. Values
+// containing the id_separator are rejected, as the ID could not be split
+// into its components unambiguously.
+func getObjectID(data map[string]any, opts *ObjectOptions) (string, error) {
+	if len(opts.IDAttributes) == 0 {
+		return utils.GetStringAtKey(data, opts.IDAttribute)
+	}
+
+	values := make([]string, 0, len(opts.IDAttributes))
+
+	for _, attr := range opts.IDAttributes {
+		value, err := utils.GetStringAtKey(data, attr)
+		if err != nil {
+			return "", err
+		}
+
+		if strings.Contains(value, opts.IDSeparator) {
+			return "", fmt.Errorf("%w: value '%s' of id_attribute '%s' contains id_separator '%s'",
+				ErrInvalidObjectID, value, attr, opts.IDSeparator)
+		}
+
+		values = append(values, value)
+	}
+
+	return strings.Join(values, opts.IDSeparator), nil
+}
+
+// idAttributeNames returns a printable representation of the configured id attributes.
+func idAttributeNames(opts *ObjectOptions) string {
+	if len(opts.IDAttributes) == 0 {
+		return opts.IDAttribute
+	}
+
+	return strings.Join(opts.IDAttributes, ",")
+}
+
+// idComponents splits a composite object ID into its components
+// mapped by the respective id_attributes.
+func (ro *RestObject) idComponents() (map[string]string, error) {
+	opts := ro.Options
+	components := make(map[string]string, len(opts.IDAttributes))
+
+	if opts.ID == "" || len(opts.IDAttributes) == 0 {
+		return components, nil
+	}
+
+	values := strings.Split(opts.ID, opts.IDSeparator)
+	if len(values) != len(opts.IDAttributes) {
+		return nil, fmt.Errorf("%w: id '%s' does not match id_attributes '%s' separated by '%s'",
+			ErrInvalidObjectID, opts.ID, idAttributeNames(opts), opts.IDSeparator)
+	}
+
+	for i, attr := range opts.IDAttributes {
+		components[attr] = values[i]
+	}
+
+	return components, nil
+}
@@ -0,0 +1,149 @@
+package restobject
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils/mockclient"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompositeID(t *testing.T) {
+	client := mockclient.New(t, &restclient.ClientOptions{})
+
+	tests := []struct {
+		name    string
+		opts    *ObjectOptions
+		path    string
+		wantID  string
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "single id",
+			opts:   &ObjectOptions{Data: APIPayload{"id": "1"}},
+			path:   "/things/{id}",
+			wantID: "1",
+			want:   "/things/1",
+		},
+		{
+			name: "composite id",
+			opts: &ObjectOptions{
+				IDAttributes: []string{"org", "slug"},
+				Data:         APIPayload{"org": "acme", "slug": "devs"},
+			},
+			path:   "/orgs/{id.org}/teams/{id.slug}",
+			wantID: "acme:devs",
+			want:   "/orgs/acme/teams/devs",
+		},
+		{
+			name: "composite id with separator",
+			opts: &ObjectOptions{
+				IDAttributes: []string{"org", "slug"},
+				IDSeparator:  "/",
+				Data:         APIPayload{"org": "acme", "slug": "devs ops"},
+			},
+			path:   "/orgs/{id.org}/teams/{id.slug}/{id}",
+			wantID: "acme/devs ops",
+			want:   "/orgs/acme/teams/devs%20ops/acme%2Fdevs%20ops",
+		},
+		{
+			name: "raw composite id",
+			opts: &ObjectOptions{
+				IDAttributes: []string{"org", "slug"},
+				IDSeparator:  "/",
+				RawID:        true,
+				Data:         APIPayload{"org": "acme", "slug": "devs ops"},
+			},
+			path:   "/orgs/{id.org}/teams/{id.slug}/{id}",
+			wantID: "acme/devs ops",
+			want:   "/orgs/acme/teams/devs ops/acme/devs ops",
+		},
+		{
+			name: "nested composite id",
+			opts: &ObjectOptions{
+				IDAttributes: []string{"owner/login", "name"},
+				Data:         APIPayload{"owner": map[string]any{"login": "acme"}, "name": "repo"},
+			},
+			path:   "/repos/{id.owner/login}/{id.name}",
+			wantID: "acme:repo",
+			want:   "/repos/acme/repo",
+		},
+		{
+			name: "invalid composite id",
+			opts: &ObjectOptions{
+				ID:           "acme",
+				IDAttributes: []string{"org", "slug"},
+			},
+			path:    "/orgs/{id.org}/teams/{id.slug}",
+			wantID:  "acme",
+			wantErr: ErrInvalidObjectID,
+		},
+		{
+			name: "ambiguous composite id",
+			opts: &ObjectOptions{
+				ID:           "acme:devs:ops",
+				IDAttributes: []string{"org", "slug"},
+			},
+			path:    "/orgs/{id.org}/teams/{id.slug}",
+			wantID:  "acme:devs:ops",
+			wantErr: ErrInvalidObjectID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Path = "/things"
+
+			ro, err := New(client, tt.opts)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantID, ro.Options.ID)
+
+			got, err := ro.expandPath(tt.path)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCompositeIDSeparator(t *testing.T) {
+	opts := &ObjectOptions{
+		IDAttributes: []string{"org", "slug"},
+		IDSeparator:  "/",
+	}
+
+	_, err := getObjectID(APIPayload{"org": "acme", "slug": "devs/ops"}, opts)
+
+	assert.ErrorIs(t, err, ErrInvalidObjectID)
+}
+
+func TestCompositeIDRead(t *testing.T) {
+	client := mockclient.New(t, &restclient.ClientOptions{})
+
+	httpmock.RegisterResponder(
+		client.Options.ReadMethod,
+		"https://restapi.local/orgs/acme/teams/devs",
+		httpmock.NewStringResponder(http.StatusOK, `{"org": "acme", "slug": "devs", "members": 3}`),
+	)
+
+	ro, _ := New(client, &ObjectOptions{
+		ID:           "acme:devs",
+		IDAttributes: []string{"org", "slug"},
+		GetPath:      "/orgs/{id.org}/teams/{id.slug}",
+	})
+
+	err := ro.Read(t.Context())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "acme:devs", ro.Options.ID)
+	assert.EqualValues(t, APIResponse{"org": "acme", "slug": "devs", "members": float64(3)}, ro.Options.APIResponse)
+}
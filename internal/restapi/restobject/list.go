@@ -0,0 +1,214 @@
+package restobject
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var ErrListObjects = errors.New("failed to list objects")
+
+// ListOptions holds the options to list the objects of a collection.
+type ListOptions struct {
+	QueryString string
+	ResultKey   string
+	// NextKey is the slash-delimited key path to the path or URL of the next
+	// page in the response. Pagination is disabled if not set.
+	NextKey string
+	// Limit is the maximum number of listed objects. Unlimited if zero.
+	Limit int
+}
+
+// List retrieves the objects of the collection at the API path. It issues a GET
+// request to the path, optionally adding the query string, and extracts the
+// result array at the result key like Find. If a next key is set, the following
+// pages are requested from the path or URL at this key in the response until it
+// is missing or empty. Each object is returned as RestObject with the ID and the
+// API response set from the listed object.
+func (ro *RestObject) List(ctx context.Context, listOpts *ListOptions) ([]*RestObject, error) {
+	ctx, span := ro.client.StartSpan(ctx, "restobject.list",
+		attribute.String("restapi.object.path", ro.Options.Path),
+	)
+
+	objects, err := ro.list(ctx, listOpts)
+
+	span.SetAttributes(attribute.Int("restapi.object.count", len(objects)))
+	ro.client.EndSpan(ctx, span, err)
+
+	return objects, err
+}
+
+func (ro *RestObject) list(ctx context.Context, listOpts *ListOptions) ([]*RestObject, error) {
+	opts := ro.Options
+	objects := make([]*RestObject, 0)
+	seen := make(map[string]bool)
+
+	listPath, err := ro.expandPath(opts.Path)
+	if err != nil {
+		return objects, err
+	}
+
+	if listOpts.QueryString != "" {
+		tflog.Debug(ctx, fmt.Sprintf("add query string '%s'", listOpts.QueryString))
+		listPath = fmt.Sprintf("%s?%s", listPath, listOpts.QueryString)
+	}
+
+	resultKey := listOpts.ResultKey
+	if resultKey == "" && opts.isJSONAPI() {
+		resultKey = jsonAPIKey
+	}
+
+	for listPath != "" {
+		var result any
+
+		if seen[listPath] {
+			return objects, fmt.Errorf("%w: page '%s' already listed", ErrListObjects, listPath)
+		}
+
+		seen[listPath] = true
+
+		tflog.Debug(ctx, fmt.Sprintf("call api with path '%s'", listPath))
+
+		listResp, err := ro.client.DoRequest(ctx, &restclient.Request{
+			Method:        ro.client.Options.ReadMethod,
+			Path:          listPath,
+			SensitiveKeys: ro.sensitiveKeys(resultKey),
+		})
+		if err != nil {
+			return objects, err
+		}
+
+		resultString, err := ro.client.Codecs.Decode(opts.ResponseFormat, listResp)
+		if err != nil {
+			return objects, err
+		}
+
+		if err := json.Unmarshal([]byte(resultString), &result); err != nil {
+			return objects, err
+		}
+
+		dataArray, err := getDataArray(result, resultKey, ro.isXMLResponse(listResp))
+		if err != nil {
+			return objects, err
+		}
+
+		for _, item := range dataArray {
+			hash, ok := item.(map[string]any)
+			if !ok {
+				return objects, fmt.Errorf("%w: data not a map of key value pairs", ErrListObjects)
+			}
+
+			if opts.isJSONAPI() {
+				hash = fromJSONAPIResource(hash)
+			}
+
+			obj, err := ro.newListedObject(ctx, hash)
+			if err != nil {
+				return objects, err
+			}
+
+			objects = append(objects, obj)
+
+			if listOpts.Limit > 0 && len(objects) >= listOpts.Limit {
+				return objects, nil
+			}
+		}
+
+		listPath, err = ro.nextPage(result, listOpts.NextKey)
+		if err != nil {
+			return objects, err
+		}
+	}
+
+	return objects, nil
+}
+
+// newListedObject returns a copy of the RestObject for the listed object. The
+// ID and the API response are synchronized from the listed object data.
+func (ro *RestObject) newListedObject(ctx context.Context, hash APIResponse) (*RestObject, error) {
+	opts := *ro.Options
+	opts.ID = ""
+	opts.Data = nil
+	opts.UpdateData = nil
+	opts.DestroyData = nil
+
+	obj := &RestObject{client: ro.client, Options: &opts}
+
+	state, err := json.Marshal(hash)
+	if err != nil {
+		return obj, err
+	}
+
+	if err := obj.syncData(ctx, string(state)); err != nil {
+		return obj, fmt.Errorf("%w: %w", ErrListObjects, err)
+	}
+
+	return obj, nil
+}
+
+// nextPage returns the path of the next page at the next key in the list
+// result. Links of the next page are resolved against the provider endpoint
+// and made relative to it. Returns an empty path if the key is not set or not
+// found in the result.
+func (ro *RestObject) nextPage(result any, nextKey string) (string, error) {
+	if nextKey == "" {
+		return "", nil
+	}
+
+	hash, ok := result.(map[string]any)
+	if !ok {
+		return "", nil
+	}
+
+	next, err := utils.GetObjectAtKey(hash, nextKey)
+	if errors.Is(err, utils.ErrObjectKeyNotFound) || next == nil {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("%w: %w: next_key '%s'", ErrListObjects, err, nextKey)
+	}
+
+	nextPath, ok := next.(string)
+	if !ok {
+		return "", fmt.Errorf("%w: next_key '%s': not a string but '%T'", ErrListObjects, nextKey, next)
+	}
+
+	return ro.endpointPath(nextPath)
+}
+
+// endpointPath returns the path and query of the link relative to the provider
+// endpoint. Links to other hosts than the endpoint are rejected, as the
+// requests would be sent with the credentials of the provider.
+func (ro *RestObject) endpointPath(link string) (string, error) {
+	endpoint, err := url.Parse(strings.TrimRight(ro.client.Options.Endpoint, "/") + "/")
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrListObjects, err)
+	}
+
+	next, err := url.Parse(link)
+	if err != nil {
+		return "", fmt.Errorf("%w: next page '%s': %w", ErrListObjects, link, err)
+	}
+
+	next = endpoint.ResolveReference(next)
+	if next.Scheme != endpoint.Scheme || next.Host != endpoint.Host {
+		return "", fmt.Errorf("%w: next page '%s' not on the endpoint host '%s'", ErrListObjects, link, endpoint.Host)
+	}
+
+	result := strings.TrimPrefix(next.EscapedPath(), strings.TrimSuffix(endpoint.EscapedPath(), "/"))
+	if next.RawQuery != "" {
+		result = fmt.Sprintf("%s?%s", result, next.RawQuery)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,220 @@
+package restobject
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils/mockclient"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	client := mockclient.New(t, &restclient.ClientOptions{RateLimit: 100})
+
+	httpmock.RegisterResponder(
+		client.Options.ReadMethod,
+		"https://restapi.local/things",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, newTestObjectList(t,
+			testObjectData["normal"], testObjectData["minimal"], testObjectData["pet"])),
+	)
+	httpmock.RegisterResponder(
+		client.Options.ReadMethod,
+		"https://restapi.local/pages?limit=1",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{
+			"items": newTestObjectList(t, testObjectData["normal"]),
+			"links": map[string]any{"next": "https://restapi.local/pages?page=2"},
+		}),
+	)
+	httpmock.RegisterResponder(
+		client.Options.ReadMethod,
+		"https://restapi.local/pages?page=2",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{
+			"items": newTestObjectList(t, testObjectData["minimal"]),
+			"links": map[string]any{"next": "/pages?page=3"},
+		}),
+	)
+	httpmock.RegisterResponder(
+		client.Options.ReadMethod,
+		"https://restapi.local/pages?page=3",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{
+			"items": newTestObjectList(t, testObjectData["pet"]),
+			"links": map[string]any{"next": nil},
+		}),
+	)
+	httpmock.RegisterResponder(
+		client.Options.ReadMethod,
+		"https://restapi.local/loop",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, map[string]any{
+			"items": newTestObjectList(t, testObjectData["minimal"]),
+			"next":  "/loop",
+		}),
+	)
+	httpmock.RegisterResponder(
+		client.Options.ReadMethod,
+		"https://restapi.local/invalid",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, []string{"a", "b"}),
+	)
+
+	tests := []struct {
+		name     string
+		path     string
+		listOpts *ListOptions
+		wantIDs  []string
+		wantErr  error
+	}{
+		{
+			name:     "list array",
+			path:     "/things",
+			listOpts: &ListOptions{},
+			wantIDs:  []string{"1", "2", "5"},
+		},
+		{
+			name:     "list with limit",
+			path:     "/things",
+			listOpts: &ListOptions{Limit: 2},
+			wantIDs:  []string{"1", "2"},
+		},
+		{
+			name:     "list pages",
+			path:     "/pages",
+			listOpts: &ListOptions{QueryString: "limit=1", ResultKey: "items", NextKey: "links/next"},
+			wantIDs:  []string{"1", "2", "5"},
+		},
+		{
+			name:     "list first page only",
+			path:     "/pages",
+			listOpts: &ListOptions{QueryString: "limit=1", ResultKey: "items"},
+			wantIDs:  []string{"1"},
+		},
+		{
+			name:     "page loop",
+			path:     "/loop",
+			listOpts: &ListOptions{ResultKey: "items", NextKey: "next"},
+			wantErr:  ErrListObjects,
+		},
+		{
+			name:     "invalid data",
+			path:     "/invalid",
+			listOpts: &ListOptions{},
+			wantErr:  ErrListObjects,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ro, _ := New(client, &ObjectOptions{Path: tt.path})
+
+			got, err := ro.List(t.Context(), tt.listOpts)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+
+			ids := make([]string, 0, len(got))
+			for _, obj := range got {
+				assert.Equal(t, obj.Options.ID, obj.Options.APIResponse["id"])
+
+				ids = append(ids, obj.Options.ID)
+			}
+
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestListNextPage(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		next     string
+		want     string
+		wantErr  error
+	}{
+		{
+			name:     "absolute url",
+			endpoint: "https://restapi.local/",
+			next:     "https://restapi.local/pages?page=2",
+			want:     "/pages?page=2",
+		},
+		{
+			name:     "absolute path",
+			endpoint: "https://restapi.local/api/v1",
+			next:     "/api/v1/pages?page=2",
+			want:     "/pages?page=2",
+		},
+		{
+			name:     "relative path",
+			endpoint: "https://restapi.local/api/v1",
+			next:     "pages?page=2",
+			want:     "/pages?page=2",
+		},
+		{
+			name:     "absolute url with base path",
+			endpoint: "https://restapi.local/api/v1/",
+			next:     "https://restapi.local/api/v1/pages?cursor=a%2Bb",
+			want:     "/pages?cursor=a%2Bb",
+		},
+		{
+			name:     "other host",
+			endpoint: "https://restapi.local/",
+			next:     "https://example.com/pages?page=2",
+			wantErr:  ErrListObjects,
+		},
+		{
+			name:     "other scheme",
+			endpoint: "https://restapi.local/",
+			next:     "http://restapi.local/pages?page=2",
+			wantErr:  ErrListObjects,
+		},
+		{
+			name:     "invalid url",
+			endpoint: "https://restapi.local/",
+			next:     "https://restapi.local/%zz",
+			wantErr:  ErrListObjects,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := mockclient.New(t, &restclient.ClientOptions{Endpoint: tt.endpoint})
+			ro, _ := New(client, &ObjectOptions{Path: "/pages"})
+
+			got, err := ro.nextPage(map[string]any{"next": tt.next}, "next")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestListTracing(t *testing.T) {
+	client, collector := mockclient.NewTraced(t, &restclient.ClientOptions{})
+
+	httpmock.RegisterResponder(
+		client.Options.ReadMethod,
+		"https://restapi.local/things",
+		httpmock.NewJsonResponderOrPanic(http.StatusOK, newTestObjectList(t, testObjectData["minimal"])),
+	)
+
+	ro, err := New(client, &ObjectOptions{Path: "/things"})
+	assert.NoError(t, err)
+
+	_, err = ro.List(t.Context(), &ListOptions{})
+	assert.NoError(t, err)
+
+	spans := collector.Spans()
+
+	testutils.AssertChildSpan(t, spans, "restobject.list", client.Options.ReadMethod)
+}
@@ -3,15 +3,19 @@ package restobject
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"path/filepath"
+	"slices"
 
 	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
 	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var ErrInvalidObjectOptions = errors.New("invalid object options")
@@ -44,14 +48,28 @@ type ObjectOptions struct {
 	ReadSearch   *ReadSearch
 	ID           string
 	IDAttribute  string
+	IDAttributes []string
+	IDSeparator  string
+	RawID        bool
+
+	RequestEnvelope string
+	ResponseKey     string
+	JSONAPI         *bool // Defaults to the client option if nil
+	RequestFormat   string
+	RequestFiles    map[string]string
+	ResponseFormat  string
+	XMLRoot         string
+	SensitiveKeys   []string
 
 	// Set internally
 	Data              APIPayload  // Data as managed by the user
 	UpdateData        APIPayload  // Update data as managed by the user
 	DestroyData       APIPayload  // Destroy data as managed by the user
-	APIResponse       APIResponse // Data as available from the API
+	SecretData        APIPayload  // Write-only data merged into the request data, never stored
+	APIResponse       APIResponse // Data as available from the API, with masked sensitive keys
 	APIResponseRaw    string
 	CreateResponseRaw string
+	SensitiveValues   APIResponse // Values of the sensitive keys in the API response
 }
 
 type ReadSearch struct {
@@ -73,6 +91,10 @@ func New(client *restclient.RestClient, opts *ObjectOptions) (*RestObject, error
 		opts.IDAttribute = client.Options.IDAttribute
 	}
 
+	if opts.IDSeparator == "" {
+		opts.IDSeparator = DefaultIDSeparator
+	}
+
 	if opts.CreateMethod == "" {
 		opts.CreateMethod = client.Options.CreateMethod
 	}
@@ -109,18 +131,56 @@ func New(client *restclient.RestClient, opts *ObjectOptions) (*RestObject, error
 		opts.ReadSearch = &ReadSearch{}
 	}
 
+	if opts.RequestEnvelope == "" {
+		opts.RequestEnvelope = client.Options.RequestEnvelope
+	}
+
+	if opts.ResponseKey == "" {
+		opts.ResponseKey = client.Options.ResponseKey
+	}
+
+	if opts.JSONAPI == nil {
+		jsonAPI := client.Options.JSONAPI
+		opts.JSONAPI = &jsonAPI
+	}
+
+	if opts.RequestFormat == "" {
+		opts.RequestFormat = client.Options.RequestFormat
+	}
+
+	if opts.ResponseFormat == "" {
+		opts.ResponseFormat = client.Options.ResponseFormat
+	}
+
+	for name, format := range map[string]string{
+		"request_format":  opts.RequestFormat,
+		"response_format": opts.ResponseFormat,
+	} {
+		if format == "" {
+			continue
+		}
+
+		if _, err := client.Codecs.Get(format); err != nil {
+			return ro, fmt.Errorf("%w: %s: %w", ErrInvalidObjectOptions, name, err)
+		}
+	}
+
+	if opts.XMLRoot == "" {
+		opts.XMLRoot = client.Options.XMLRoot
+	}
+
 	// Opportunistically set the object's ID if it is provided in the data.
 	// If it is not set, we will get it later in synchronize_state.
 	if opts.Data != nil && opts.ID == "" {
 		var tmp string
 
-		tmp, err := utils.GetStringAtKey(opts.Data, opts.IDAttribute)
+		tmp, err := getObjectID(opts.Data, opts)
 		if err == nil {
 			opts.ID = tmp
 		} else if !client.Options.WriteReturnsObject && !client.Options.CreateReturnsObject && opts.Path == "" {
 			// If the id is not set and we cannot obtain it later, error out to be safe.
 			return ro, fmt.Errorf("%w: object can not be managed: id_attribute '%s' not found in object "+
-				"and write_returns_object or create_returns_object not set", ErrInvalidObjectOptions, opts.IDAttribute)
+				"and write_returns_object or create_returns_object not set", ErrInvalidObjectOptions, idAttributeNames(opts))
 		}
 	}
 
@@ -130,6 +190,11 @@ func New(client *restclient.RestClient, opts *ObjectOptions) (*RestObject, error
 	return ro, nil
 }
 
+// isJSONAPI reports whether the JSON:API mode is enabled.
+func (opts *ObjectOptions) isJSONAPI() bool {
+	return opts.JSONAPI != nil && *opts.JSONAPI
+}
+
 // ToString returns a string representation of the RestObject options.
 func (ro *RestObject) ToString() string {
 	var buffer bytes.Buffer
@@ -137,6 +202,8 @@ func (ro *RestObject) ToString() string {
 	opts := ro.Options
 
 	fmt.Fprintf(&buffer, "id: %s\n", opts.ID)
+	fmt.Fprintf(&buffer, "id_attributes: %s\n", idAttributeNames(opts))
+	fmt.Fprintf(&buffer, "raw_id: %t\n", opts.RawID)
 	fmt.Fprintf(&buffer, "get_path: %s\n", opts.GetPath)
 	fmt.Fprintf(&buffer, "post_path: %s\n", opts.PostPath)
 	fmt.Fprintf(&buffer, "put_path: %s\n", opts.PutPath)
@@ -147,23 +214,159 @@ func (ro *RestObject) ToString() string {
 	fmt.Fprintf(&buffer, "update_method: %s\n", opts.UpdateMethod)
 	fmt.Fprintf(&buffer, "destroy_method: %s\n", opts.DeleteMethod)
 	fmt.Fprintf(&buffer, "read_search: %s\n", spew.Sdump(opts.ReadSearch))
-	fmt.Fprintf(&buffer, "data: %s\n", spew.Sdump(opts.Data))
-	fmt.Fprintf(&buffer, "update_data: %s\n", spew.Sdump(opts.UpdateData))
-	fmt.Fprintf(&buffer, "destroy_data: %s\n", spew.Sdump(opts.DestroyData))
+	fmt.Fprintf(&buffer, "request_envelope: %s\n", opts.RequestEnvelope)
+	fmt.Fprintf(&buffer, "response_key: %s\n", opts.ResponseKey)
+	fmt.Fprintf(&buffer, "jsonapi: %t\n", opts.isJSONAPI())
+	fmt.Fprintf(&buffer, "request_format: %s\n", opts.RequestFormat)
+	fmt.Fprintf(&buffer, "request_files: %v\n", opts.RequestFiles)
+	fmt.Fprintf(&buffer, "response_format: %s\n", opts.ResponseFormat)
+	fmt.Fprintf(&buffer, "xml_root: %s\n", opts.XMLRoot)
+	fmt.Fprintf(&buffer, "sensitive_keys: %v\n", opts.SensitiveKeys)
+
+	// Only dump masked values of the sensitive keys.
+	data, _ := utils.RedactKeys(opts.Data, opts.SensitiveKeys)
+	updateData, _ := utils.RedactKeys(opts.UpdateData, opts.SensitiveKeys)
+	destroyData, _ := utils.RedactKeys(opts.DestroyData, opts.SensitiveKeys)
+
+	fmt.Fprintf(&buffer, "data: %s\n", spew.Sdump(data))
+	fmt.Fprintf(&buffer, "update_data: %s\n", spew.Sdump(updateData))
+	fmt.Fprintf(&buffer, "destroy_data: %s\n", spew.Sdump(destroyData))
 	fmt.Fprintf(&buffer, "api_response: %s\n", spew.Sdump(opts.APIResponse))
 
 	return buffer.String()
 }
 
+// encodeRequestData encodes the data, or the overwrite data if set, with the codec
+// of the configured request format and returns the request body and its content type.
+// Files are only attached to multipart bodies. Returns an empty body if there is no
+// data to send.
+func (ro *RestObject) encodeRequestData(data, overwrite APIPayload, files map[string]string) (string, string, error) {
+	var (
+		codec restclient.Codec
+		err   error
+	)
+
+	if overwrite != nil {
+		data = overwrite
+	}
+
+	if data == nil && (ro.Options.RequestFormat != restclient.FormatMultipart || len(files) == 0) {
+		return "", "", nil
+	}
+
+	switch ro.Options.RequestFormat {
+	case restclient.FormatMultipart:
+		codec = &restclient.MultipartCodec{Files: files}
+	case restclient.FormatXML:
+		codec = &restclient.XMLCodec{Root: ro.Options.XMLRoot}
+	default:
+		codec, err = ro.client.Codecs.Get(ro.Options.RequestFormat)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	return codec.Encode(ro.wrapRequestData(data))
+}
+
+// startSpan starts the span of an operation of the object, see
+// restclient.RestClient.StartSpan.
+func (ro *RestObject) startSpan(ctx context.Context, operation string) (context.Context, trace.Span) {
+	return ro.client.StartSpan(ctx, "restobject."+operation,
+		attribute.String("restapi.object.path", ro.Options.Path),
+	)
+}
+
+// endSpan ends the span of an operation of the object with the ID of the
+// object, which might have been set by the operation.
+func (ro *RestObject) endSpan(ctx context.Context, span trace.Span, err error) {
+	span.SetAttributes(attribute.String("restapi.object.id", ro.Options.ID))
+	ro.client.EndSpan(ctx, span, err)
+}
+
+// do sends the request to the API like restclient.RestClient.Do and redacts
+// the sensitive keys from the logs of the request and response bodies.
+func (ro *RestObject) do(ctx context.Context, method, path, data, contentType string) (*restclient.Response, error) {
+	return ro.client.DoRequest(ctx, &restclient.Request{
+		Method:        method,
+		Path:          path,
+		Body:          data,
+		ContentType:   contentType,
+		SensitiveKeys: ro.sensitiveKeys(""),
+	})
+}
+
+// sensitiveKeys returns the sensitive keys of the object relative to request
+// and response bodies, which might wrap the object into an envelope or into
+// a result array at the given result key.
+func (ro *RestObject) sensitiveKeys(resultKey string) []string {
+	keys := ro.Options.SensitiveKeys
+	if len(keys) == 0 {
+		return nil
+	}
+
+	prefixes := []string{ro.requestEnvelope(), ro.responseKey()}
+
+	if ro.Options.isJSONAPI() {
+		prefixes = append(prefixes, jsonAPIKey+"/attributes")
+	}
+
+	if resultKey != "" {
+		prefixes = append(prefixes, resultKey+"/*")
+	}
+
+	result := slices.Clone(keys)
+
+	for _, prefix := range prefixes {
+		if prefix = utils.SanitizePath(prefix); prefix == "" {
+			continue
+		}
+
+		for _, key := range keys {
+			result = append(result, prefix+"/"+key)
+		}
+	}
+
+	return result
+}
+
+// withSecretData returns the data deep-merged with the secret data. Returns
+// the unmodified data if it is nil or there is no secret data.
+func (ro *RestObject) withSecretData(data APIPayload) APIPayload {
+	if data == nil || ro.Options.SecretData == nil {
+		return data
+	}
+
+	return utils.MergeMaps(data, ro.Options.SecretData)
+}
+
 // setData updates the RestObject's data from the provided API response.
+// It decodes the response in the configured response format, or the format
+// matching its content type, extracts the object from the configured response
+// envelope and synchronizes the object data with it.
+func (ro *RestObject) setData(ctx context.Context, resp *restclient.Response) error {
+	state, err := ro.client.Codecs.Decode(ro.Options.ResponseFormat, resp)
+	if err != nil {
+		return err
+	}
+
+	state, err = ro.unwrapResponse(state)
+	if err != nil {
+		return err
+	}
+
+	return ro.syncData(ctx, state)
+}
+
+// syncData updates the RestObject's data from the provided API object.
 // It extracts the ID if not already set, copies configured keys from the
 // API response to the data, and stores the raw API response.
-func (ro *RestObject) setData(ctx context.Context, state string) error {
+func (ro *RestObject) syncData(ctx context.Context, state string) error {
 	var err error
 
 	opts := ro.Options
 
-	tflog.Debug(ctx, fmt.Sprintf("update api object data: '%s'", state))
+	tflog.Debug(ctx, fmt.Sprintf("update api object data: '%s'", utils.RedactJSONString(state, opts.SensitiveKeys)))
 
 	// Store filtered response body.
 	opts.APIResponse, opts.APIResponseRaw, err = utils.FilterJSONString(
@@ -172,10 +375,26 @@ func (ro *RestObject) setData(ctx context.Context, state string) error {
 		return err
 	}
 
+	// Never store the secret data, even if the API returns it, and mask the
+	// sensitive keys. The unmasked response is only used to sync the data.
+	response := opts.APIResponse
+
+	if opts.SecretData != nil || len(opts.SensitiveKeys) > 0 {
+		response = utils.ExcludeMaps(response, opts.SecretData)
+		opts.APIResponse, opts.SensitiveValues = utils.RedactKeys(response, opts.SensitiveKeys)
+
+		raw, err := json.Marshal(opts.APIResponse)
+		if err != nil {
+			return err
+		}
+
+		opts.APIResponseRaw = string(raw)
+	}
+
 	// A usable ID was not passed (in constructor or here),
 	// so we have to guess what it is from the data structure.
 	if opts.ID == "" {
-		val, err := utils.GetStringAtKey(opts.APIResponse, opts.IDAttribute)
+		val, err := getObjectID(response, opts)
 		if err != nil {
 			return fmt.Errorf("error extracting id from data element: %w", err)
 		}
@@ -191,18 +410,32 @@ func (ro *RestObject) setData(ctx context.Context, state string) error {
 		return nil
 	}
 
+	// Only log masked values of the sensitive keys.
+	data, _ := utils.RedactKeys(opts.Data, opts.SensitiveKeys)
+
 	if len(ro.client.Options.CopyKeys) > 0 {
 		// Any keys that come from the data we want to copy are done here
 		for _, key := range ro.client.Options.CopyKeys {
+			if _, ok := opts.SecretData[key]; ok {
+				continue
+			}
+
 			tflog.Debug(ctx, fmt.Sprintf("copy key '%s' from api_response (%v) to data (%v)",
-				key, opts.APIResponse[key], opts.Data[key]))
+				key, opts.APIResponse[key], data[key]))
 
-			opts.Data[key] = opts.APIResponse[key]
+			opts.Data[key] = response[key]
 		}
 	} else if ro.client.Options.DriftDetection {
-		for key, value := range utils.IntersectMaps(opts.Data, opts.APIResponse) {
+		// Keys of the secret data are skipped by keeping the values of the data,
+		// as the API response of the secret data is not stored.
+		response = utils.MergeMaps(response, utils.IntersectMaps(opts.SecretData, opts.Data))
+		// Values are compared by their string form, as untyped responses like
+		// XML documents would otherwise always differ from numbers and booleans.
+		response = utils.MatchScalarTypes(opts.Data, response)
+
+		for key, value := range utils.IntersectMaps(opts.Data, response) {
 			tflog.Debug(ctx, fmt.Sprintf("copy key '%s' from api_response (%v) to data (%v)",
-				key, value, opts.Data[key]))
+				key, opts.APIResponse[key], data[key]))
 
 			opts.Data[key] = value
 		}
@@ -3,10 +3,6 @@ package restobject
 import (
 	"encoding/json"
 	"testing"
-
-	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
-
-	"github.com/jarcoal/httpmock"
 )
 
 type testObject struct {
@@ -112,21 +108,3 @@ func mapToTestObject(t *testing.T, resp APIResponse) testObject {
 
 	return newTestObject(t, string(jsonStr))
 }
-
-func newMockClient(t *testing.T, opts *restclient.ClientOptions) *restclient.RestClient {
-	t.Helper()
-
-	if opts.Endpoint == "" {
-		opts.Endpoint = "https://restapi.local/"
-	}
-
-	client, _ := restclient.New(t.Context(), opts)
-
-	httpmock.ActivateNonDefault(client.HTTPClient)
-
-	t.Cleanup(func() {
-		httpmock.DeactivateAndReset()
-	})
-
-	return client
-}
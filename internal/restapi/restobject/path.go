@@ -0,0 +1,138 @@
+package restobject
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var ErrPathTemplate = errors.New("failed to expand path template")
+
+// expandPath replaces the placeholders in the given path. Supported placeholders are:
+//
+//	{id}               the object ID
+//	{id.<attribute>}   the respective component of a composite ID
+//	{data.<path>}      the value at the key path in the object data
+//	{response.<path>}  the value at the key path in the last API response (APIResponse)
+//	{env.<name>}       the value of the environment variable
+//
+// All values are URL path escaped. The object ID and its components are inserted
+// unescaped if RawID is set. Key paths are slash-delimited, e.g. `{data.project/id}`.
+func (ro *RestObject) expandPath(path string) (string, error) {
+	return ro.expandTemplate(path, url.PathEscape)
+}
+
+// expandQuery replaces the placeholders in the given query string like expandPath,
+// but the values are URL query escaped.
+func (ro *RestObject) expandQuery(query string) (string, error) {
+	return ro.expandTemplate(query, url.QueryEscape)
+}
+
+// requestPath expands the placeholders in the given path and appends the
+// expanded query string of the object, if any.
+func (ro *RestObject) requestPath(ctx context.Context, path string) (string, error) {
+	result, err := ro.expandPath(path)
+	if err != nil {
+		return "", err
+	}
+
+	if ro.Options.QueryString == "" {
+		return result, nil
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("add query string '%s'", ro.Options.QueryString))
+
+	query, err := ro.expandQuery(ro.Options.QueryString)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s?%s", result, query), nil
+}
+
+// expandTemplate replaces the placeholders in the given string by the values
+// escaped with the given function.
+func (ro *RestObject) expandTemplate(template string, escape func(string) string) (string, error) {
+	components, err := ro.idComponents()
+	if err != nil {
+		return "", err
+	}
+
+	scopes := []string{"id", "data", "response", "env"}
+
+	result, err := utils.ExpandPlaceholders(template, scopes, func(scope, key string) (string, error) {
+		return ro.resolvePlaceholder(scope, key, components, escape)
+	})
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrPathTemplate, err)
+	}
+
+	return result, nil
+}
+
+// resolvePlaceholder returns the escaped value for a placeholder of the given scope and key.
+func (ro *RestObject) resolvePlaceholder(
+	scope, key string, components map[string]string, escape func(string) string,
+) (string, error) {
+	opts := ro.Options
+
+	switch scope {
+	case "id":
+		value, ok := opts.ID, true
+		if key != "" {
+			value, ok = components[key]
+		}
+
+		if !ok {
+			if slices.Contains(opts.IDAttributes, key) {
+				return "", fmt.Errorf("%w: '%s' not available: object has no id yet", utils.ErrObjectKeyNotFound, key)
+			}
+
+			return "", fmt.Errorf("%w: '%s' not in id_attributes", utils.ErrObjectKeyNotFound, key)
+		}
+
+		if opts.RawID {
+			return value, nil
+		}
+
+		return escape(value), nil
+	case "data":
+		return lookupValue(opts.Data, "data", key, escape)
+	case "response":
+		return lookupValue(opts.APIResponse, "response", key, escape)
+	case "env":
+		value, ok := os.LookupEnv(key)
+		if !ok {
+			return "", fmt.Errorf("%w: environment variable '%s' not set", utils.ErrObjectKeyNotFound, key)
+		}
+
+		return escape(value), nil
+	}
+
+	return "", fmt.Errorf("%w: unknown placeholder scope '%s'", ErrPathTemplate, scope)
+}
+
+// lookupValue returns the escaped value at the key path in the given data.
+func lookupValue(data map[string]any, name, key string, escape func(string) string) (string, error) {
+	if key == "" {
+		return "", fmt.Errorf("%w: no key given for %s", utils.ErrObjectKeyNotFound, name)
+	}
+
+	if data == nil {
+		return "", fmt.Errorf("%w: key '%s' not available: %s is empty", utils.ErrObjectKeyNotFound, key, name)
+	}
+
+	value, err := utils.GetStringAtKey(data, key)
+	if err != nil {
+		return "", err
+	}
+
+	return escape(value), nil
+}
@@ -0,0 +1,217 @@
+package restobject
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils/mockclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandPath(t *testing.T) {
+	client := mockclient.New(t, &restclient.ClientOptions{})
+
+	t.Setenv("RESTAPI_TEST_TENANT", "acme corp")
+
+	tests := []struct {
+		name     string
+		path     string
+		id       string
+		rawID    bool
+		data     APIPayload
+		response APIResponse
+		want     string
+		wantErr  error
+		wantMsg  string
+	}{
+		{
+			name: "id",
+			path: "/things/{id}",
+			want: "/things/1",
+		},
+		{
+			name: "escaped id",
+			path: "/things/{id}",
+			id:   "a/b c?d#e",
+			want: "/things/a%2Fb%20c%3Fd%23e",
+		},
+		{
+			name:  "raw id",
+			path:  "/things/{id}",
+			id:    "a/b",
+			rawID: true,
+			want:  "/things/a/b",
+		},
+		{
+			name: "data",
+			path: "/projects/{data.project_id}/members/{id}",
+			data: APIPayload{"project_id": 42},
+			want: "/projects/42/members/1",
+		},
+		{
+			name: "nested data",
+			path: "/projects/{data.project/name}/members",
+			data: APIPayload{"project": map[string]any{"name": "foo/bar"}},
+			want: "/projects/foo%2Fbar/members",
+		},
+		{
+			name:     "response",
+			path:     "/things/{response.uuid}",
+			response: APIResponse{"uuid": "abc-123"},
+			want:     "/things/abc-123",
+		},
+		{
+			name: "env",
+			path: "/tenants/{env.RESTAPI_TEST_TENANT}/things",
+			want: "/tenants/acme%20corp/things",
+		},
+		{
+			name: "other placeholders",
+			path: "/things/{search_value}?{foo}",
+			want: "/things/{search_value}?{foo}",
+		},
+		{
+			name:    "missing data key",
+			path:    "/projects/{data.project_id}/members",
+			data:    APIPayload{"name": "foo"},
+			wantErr: utils.ErrObjectKeyNotFound,
+			wantMsg: "placeholder '{data.project_id}'",
+		},
+		{
+			name:    "missing response",
+			path:    "/things/{response.uuid}",
+			wantErr: ErrPathTemplate,
+			wantMsg: "key 'uuid' not available",
+		},
+		{
+			name:    "missing env",
+			path:    "/tenants/{env.RESTAPI_TEST_MISSING}",
+			wantErr: utils.ErrObjectKeyNotFound,
+			wantMsg: "environment variable 'RESTAPI_TEST_MISSING' not set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id := tt.id
+			if id == "" {
+				id = "1"
+			}
+
+			ro, _ := New(client, &ObjectOptions{ID: id, RawID: tt.rawID, Path: "/things", Data: tt.data})
+			ro.Options.APIResponse = tt.response
+
+			got, err := ro.expandPath(tt.path)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.ErrorContains(t, err, tt.wantMsg)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExpandPathCompositeID(t *testing.T) {
+	client := mockclient.New(t, &restclient.ClientOptions{})
+
+	tests := []struct {
+		name    string
+		id      string
+		path    string
+		want    string
+		wantMsg string
+	}{
+		{
+			name: "component",
+			id:   "acme:repo",
+			path: "/repos/{id.owner}/{id.name}",
+			want: "/repos/acme/repo",
+		},
+		{
+			name:    "unknown component",
+			id:      "acme:repo",
+			path:    "/repos/{id.owner}/{id.slug}",
+			wantMsg: "'slug' not in id_attributes",
+		},
+		{
+			name:    "no id yet",
+			path:    "/repos/{id.owner}",
+			wantMsg: "'owner' not available: object has no id yet",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ro, _ := New(client, &ObjectOptions{
+				ID: tt.id, Path: "/repos", IDAttributes: []string{"owner", "name"}, IDSeparator: ":",
+			})
+
+			got, err := ro.expandPath(tt.path)
+			if tt.wantMsg != "" {
+				assert.ErrorIs(t, err, utils.ErrObjectKeyNotFound)
+				assert.ErrorContains(t, err, tt.wantMsg)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExpandPathCreate(t *testing.T) {
+	client := mockclient.New(t, &restclient.ClientOptions{CreateReturnsObject: true})
+
+	httpmock.RegisterResponder(
+		client.Options.CreateMethod,
+		"https://restapi.local/projects/42/members",
+		httpmock.NewStringResponder(http.StatusOK, `{"id": "1", "project_id": 42, "name": "foo"}`),
+	)
+
+	ro, _ := New(client, &ObjectOptions{
+		Path: "/projects/{data.project_id}/members",
+		Data: APIPayload{"project_id": 42, "name": "foo"},
+	})
+
+	err := ro.Create(t.Context())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", ro.Options.ID)
+}
+
+func TestRequestPathQueryString(t *testing.T) {
+	client := mockclient.New(t, &restclient.ClientOptions{})
+
+	t.Setenv("RESTAPI_TEST_TENANT", "acme+corp")
+
+	var rawQuery string
+
+	httpmock.RegisterResponder(
+		http.MethodGet,
+		"https://restapi.local/things/a%2Fb",
+		func(req *http.Request) (*http.Response, error) {
+			rawQuery = req.URL.RawQuery
+
+			return httpmock.NewStringResponse(http.StatusOK, `{"id": "a/b", "name": "foo"}`), nil
+		},
+	)
+
+	ro, _ := New(client, &ObjectOptions{
+		Path:        "/things",
+		ID:          "a/b",
+		QueryString: "filter={data.filter}&tenant={env.RESTAPI_TEST_TENANT}",
+		Data:        APIPayload{"filter": "a=1&b=2"},
+	})
+
+	assert.NoError(t, ro.Read(t.Context()))
+	assert.Equal(t, "filter=a%3D1%26b%3D2&tenant=acme%2Bcorp", rawQuery)
+}
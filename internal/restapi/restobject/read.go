@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -15,23 +14,24 @@ var ErrReadObject = errors.New("failed to read object")
 
 // Read retrieves the RestObject from the API based on the configured ID and options.
 // It handles errors and unset IDs. It can also search the response and return a matched object.
-func (ro *RestObject) Read(ctx context.Context) error {
+func (ro *RestObject) Read(ctx context.Context) (err error) {
+	ctx, span := ro.startSpan(ctx, "read")
+	defer func() { ro.endSpan(ctx, span, err) }()
+
 	opts := ro.Options
 
 	if opts.ID == "" {
 		return fmt.Errorf("%w: id not set", ErrReadObject)
 	}
 
-	getPath := opts.GetPath
-
-	if opts.QueryString != "" {
-		tflog.Debug(ctx, fmt.Sprintf("add query string '%s'", opts.QueryString))
-		getPath = fmt.Sprintf("%s?%s", opts.GetPath, opts.QueryString)
+	getPath, err := ro.requestPath(ctx, opts.GetPath)
+	if err != nil {
+		return err
 	}
 
-	result, status, err := ro.client.SendRequest(ctx, opts.ReadMethod, strings.ReplaceAll(getPath, "{id}", opts.ID), "")
+	resp, err := ro.do(ctx, opts.ReadMethod, getPath, "", "")
 	if err != nil {
-		if status == http.StatusNotFound {
+		if resp.StatusCode == http.StatusNotFound {
 			tflog.Error(ctx, fmt.Sprintf("%s: failed to refresh state for '%s' at path '%s': removing from state",
 				err, opts.ID, opts.GetPath))
 
@@ -66,8 +66,9 @@ func (ro *RestObject) Read(ctx context.Context) error {
 			return err
 		}
 
-		result = string(objFoundString)
+		// The found object is already unwrapped from the response envelope.
+		return ro.syncData(ctx, string(objFoundString))
 	}
 
-	return ro.setData(ctx, result)
+	return ro.setData(ctx, resp)
 }
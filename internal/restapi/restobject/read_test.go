@@ -5,13 +5,14 @@ import (
 	"testing"
 
 	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils/mockclient"
 
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestRead(t *testing.T) {
-	client := newMockClient(t, &restclient.ClientOptions{})
+	client := mockclient.New(t, &restclient.ClientOptions{})
 
 	httpmock.RegisterResponder(
 		client.Options.ReadMethod,
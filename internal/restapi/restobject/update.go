@@ -4,9 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
-
-	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -19,20 +16,28 @@ var ErrUpdateObject = errors.New("failed to update object")
 //
 // If write_returns_object is true, it will parse the response and update the
 // RestObject. Otherwise it will re-read the object from the API after the update.
-func (ro *RestObject) Update(ctx context.Context) error {
+func (ro *RestObject) Update(ctx context.Context) (err error) {
+	ctx, span := ro.startSpan(ctx, "update")
+	defer func() { ro.endSpan(ctx, span, err) }()
+
 	opts := ro.Options
 
 	if opts.ID == "" {
 		return fmt.Errorf("%w: id not set", ErrUpdateObject)
 	}
 
-	data, err := utils.GetRequestData(opts.Data, opts.UpdateData)
+	data, contentType, err := ro.encodeRequestData(
+		ro.withSecretData(opts.Data), ro.withSecretData(opts.UpdateData), opts.RequestFiles)
+	if err != nil {
+		return err
+	}
+
+	putPath, err := ro.expandPath(opts.PutPath)
 	if err != nil {
 		return err
 	}
 
-	resultString, _, err := ro.client.SendRequest(
-		ctx, opts.UpdateMethod, strings.ReplaceAll(opts.PutPath, "{id}", opts.ID), data)
+	resp, err := ro.do(ctx, opts.UpdateMethod, putPath, data, contentType)
 	if err != nil {
 		return err
 	}
@@ -42,7 +47,7 @@ func (ro *RestObject) Update(ctx context.Context) error {
 			ro.client.Options.WriteReturnsObject,
 		))
 
-		err = ro.setData(ctx, resultString)
+		err = ro.setData(ctx, resp)
 	} else {
 		tflog.Debug(ctx, fmt.Sprintf("request updated object from API: write_returns_object=%t",
 			ro.client.Options.WriteReturnsObject))
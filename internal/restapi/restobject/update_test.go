@@ -7,13 +7,14 @@ import (
 	"testing"
 
 	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils/mockclient"
 
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestUpdate(t *testing.T) {
-	client := newMockClient(t, &restclient.ClientOptions{CreateReturnsObject: true})
+	client := mockclient.New(t, &restclient.ClientOptions{CreateReturnsObject: true})
 
 	tests := []struct {
 		name    string
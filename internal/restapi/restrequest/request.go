@@ -0,0 +1,164 @@
+package restrequest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var (
+	ErrInvalidRequestOptions = errors.New("invalid request options")
+	ErrUnexpectedStatusCode  = errors.New("unexpected status code")
+	ErrResponseKey           = errors.New("failed to extract response key")
+)
+
+type RequestOptions struct {
+	Method      string
+	Path        string
+	QueryParams map[string]string
+	Body        string
+	ContentType string
+	Headers     map[string]string
+	// ExpectedStatusCodes holds the accepted status codes of the response.
+	// If empty, all 2xx status codes are accepted.
+	ExpectedStatusCodes []int
+}
+
+// Send sends the request with the REST client and returns the response. It
+// returns an error if the status code of the response is not expected, along
+// with the response.
+func Send(ctx context.Context, client *restclient.RestClient, opts *RequestOptions) (*restclient.Response, error) {
+	ctx, span := client.StartSpan(ctx, "restrequest.send",
+		attribute.String("restapi.request.path", opts.Path),
+	)
+
+	resp, err := send(ctx, client, opts)
+	client.EndSpan(ctx, span, err)
+
+	return resp, err
+}
+
+func send(ctx context.Context, client *restclient.RestClient, opts *RequestOptions) (*restclient.Response, error) {
+	if opts.Path == "" {
+		return nil, fmt.Errorf("%w: path not set", ErrInvalidRequestOptions)
+	}
+
+	method := opts.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	reqPath, err := withQueryParams(opts.Path, opts.QueryParams)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &restclient.Request{
+		Method:      strings.ToUpper(method),
+		Path:        reqPath,
+		Body:        opts.Body,
+		ContentType: opts.ContentType,
+	}
+
+	if req.ContentType == "" && req.Body != "" {
+		req.ContentType = restclient.ContentTypeJSON
+	}
+
+	if len(opts.Headers) > 0 {
+		req.Header = make(http.Header, len(opts.Headers))
+
+		for name, value := range opts.Headers {
+			req.Header.Set(name, value)
+		}
+	}
+
+	resp, err := client.DoRequest(ctx, req)
+	if err != nil && !errors.Is(err, restclient.ErrUnexpectedResponseCode) {
+		return resp, err
+	}
+
+	if len(opts.ExpectedStatusCodes) == 0 {
+		return resp, err
+	}
+
+	if !slices.Contains(opts.ExpectedStatusCodes, resp.StatusCode) {
+		return resp, fmt.Errorf("%w: http %d not one of %v: %s",
+			ErrUnexpectedStatusCode, resp.StatusCode, opts.ExpectedStatusCodes, resp.Body)
+	}
+
+	return resp, nil
+}
+
+// withQueryParams adds the query params to the query string of the path.
+func withQueryParams(path string, params map[string]string) (string, error) {
+	if len(params) == 0 {
+		return path, nil
+	}
+
+	reqPath, rawQuery, _ := strings.Cut(path, "?")
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", fmt.Errorf("%w: query string of path '%s': %w", ErrInvalidRequestOptions, path, err)
+	}
+
+	for key, value := range params {
+		query.Set(key, value)
+	}
+
+	return fmt.Sprintf("%s?%s", reqPath, query.Encode()), nil
+}
+
+// DecodeResponse decodes the response body with the codec of the given format,
+// or the format matching its content type, and returns it as JSON document.
+// If a key is given, only the value at the slash-delimited key path is returned.
+// Elements of a top-level array are addressed by their index, e.g. `0/name`.
+func DecodeResponse(client *restclient.RestClient, resp *restclient.Response, format, key string) (string, error) {
+	body, err := client.Codecs.Decode(format, resp)
+	if err != nil {
+		return "", err
+	}
+
+	if key == "" {
+		return body, nil
+	}
+
+	var data any
+
+	if err := json.Unmarshal([]byte(body), &data); err != nil {
+		return "", fmt.Errorf("%w: response key '%s': %w", ErrResponseKey, key, err)
+	}
+
+	container, ok := data.(map[string]any)
+
+	if list, isList := data.([]any); isList {
+		container, ok = utils.IndexList(list), true
+	}
+
+	if !ok {
+		return "", fmt.Errorf("%w: response key '%s': response not an object or array but '%T'",
+			ErrResponseKey, key, data)
+	}
+
+	value, err := utils.GetObjectAtKey(container, key)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrResponseKey, err)
+	}
+
+	result, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrResponseKey, err)
+	}
+
+	return string(result), nil
+}
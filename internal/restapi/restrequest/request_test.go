@@ -0,0 +1,203 @@
+package restrequest
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils/mockclient"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSend(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       *RequestOptions
+		status     int
+		wantStatus int
+		wantErr    error
+	}{
+		{
+			name:       "default",
+			opts:       &RequestOptions{Path: "/reindex"},
+			status:     http.StatusAccepted,
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:    "unexpected status",
+			opts:    &RequestOptions{Path: "/reindex"},
+			status:  http.StatusConflict,
+			wantErr: restclient.ErrUnexpectedResponseCode,
+		},
+		{
+			name:       "expected status",
+			opts:       &RequestOptions{Path: "/reindex", ExpectedStatusCodes: []int{http.StatusOK, http.StatusConflict}},
+			status:     http.StatusConflict,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:    "status not expected",
+			opts:    &RequestOptions{Path: "/reindex", ExpectedStatusCodes: []int{http.StatusNoContent}},
+			status:  http.StatusOK,
+			wantErr: ErrUnexpectedStatusCode,
+		},
+		{
+			name:    "no path",
+			opts:    &RequestOptions{},
+			wantErr: ErrInvalidRequestOptions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := mockclient.New(t, &restclient.ClientOptions{})
+
+			httpmock.RegisterResponder(
+				http.MethodGet,
+				"https://restapi.local/reindex",
+				httpmock.NewStringResponder(tt.status, `{"status": "queued"}`),
+			)
+
+			resp, err := Send(t.Context(), client, tt.opts)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, `{"status": "queued"}`, resp.Body)
+		})
+	}
+}
+
+func TestSendRequest(t *testing.T) {
+	client := mockclient.New(t, &restclient.ClientOptions{})
+
+	httpmock.RegisterResponder(
+		http.MethodPost,
+		"https://restapi.local/keys/rotate",
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+
+			assert.Equal(t, "all", req.URL.Query().Get("scope"))
+			assert.Equal(t, "1", req.URL.Query().Get("force"))
+			assert.Equal(t, "abc", req.Header.Get("X-Request-Id"))
+			assert.Equal(t, restclient.ContentTypeJSON, req.Header.Get("Content-Type"))
+			assert.JSONEq(t, `{"reason": "scheduled"}`, string(body))
+
+			resp := httpmock.NewStringResponse(http.StatusOK, `{"id": "k2"}`)
+			resp.Header.Set("Location", "/keys/k2")
+
+			return resp, nil
+		},
+	)
+
+	resp, err := Send(t.Context(), client, &RequestOptions{
+		Method:      "post",
+		Path:        "/keys/rotate?scope=all",
+		QueryParams: map[string]string{"force": "1"},
+		Body:        `{"reason": "scheduled"}`,
+		Headers:     map[string]string{"x-request-id": "abc"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "/keys/k2", resp.Header.Get("Location"))
+}
+
+func TestDecodeResponse(t *testing.T) {
+	client := mockclient.New(t, &restclient.ClientOptions{})
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		format      string
+		key         string
+		want        string
+		wantErr     error
+	}{
+		{
+			name: "json",
+			body: `{"version": "1.2.3"}`,
+			want: `{"version": "1.2.3"}`,
+		},
+		{
+			name:        "yaml content type",
+			contentType: "application/yaml",
+			body:        "zones:\n  - name: a\n  - name: b\n",
+			key:         "zones/1",
+			want:        `{"name": "b"}`,
+		},
+		{
+			name:   "format and key",
+			body:   "<discovery><issuer>https://id.local</issuer></discovery>",
+			format: restclient.FormatXML,
+			key:    "issuer",
+			want:   `"https://id.local"`,
+		},
+		{
+			name:    "missing key",
+			body:    `{"version": "1.2.3"}`,
+			key:     "build/date",
+			wantErr: ErrResponseKey,
+		},
+		{
+			name: "key of array",
+			body: `[{"name": "a"}]`,
+			key:  "0/name",
+			want: `"a"`,
+		},
+		{
+			name:    "key of scalar",
+			body:    `"a"`,
+			key:     "0",
+			wantErr: ErrResponseKey,
+		},
+		{
+			name:        "unsupported content type",
+			contentType: "text/html",
+			body:        "<html></html>",
+			wantErr:     restclient.ErrUnsupportedMediaType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &restclient.Response{Body: tt.body, StatusCode: http.StatusOK, Header: http.Header{}}
+			if tt.contentType != "" {
+				resp.Header.Set("Content-Type", tt.contentType)
+			}
+
+			got, err := DecodeResponse(client, resp, tt.format, tt.key)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, got)
+		})
+	}
+}
+
+func TestSendTracing(t *testing.T) {
+	client, collector := mockclient.NewTraced(t, &restclient.ClientOptions{})
+
+	httpmock.RegisterResponder(http.MethodGet, "https://restapi.local/version",
+		httpmock.NewStringResponder(http.StatusOK, `{"version": "1.2.3"}`))
+
+	_, err := Send(t.Context(), client, &RequestOptions{Path: "/version"})
+	assert.NoError(t, err)
+
+	spans := collector.Spans()
+
+	testutils.AssertChildSpan(t, spans, "restrequest.send", http.MethodGet)
+}
@@ -0,0 +1,101 @@
+package restrequest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"mime"
+	"net/url"
+	"strings"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/utils"
+)
+
+var ErrRequestTemplate = errors.New("failed to expand request template")
+
+// ExpandResponse returns a copy of the request options with the placeholders
+// `{response.<key>}` replaced by the value at the slash-delimited key path in the
+// JSON document of a previous response, e.g. `{response.session/token}`. Values
+// are URL path escaped in the path and inserted verbatim into the query params
+// and the headers. In the body, values are escaped as JSON string content for
+// JSON bodies, the default, and URL query escaped for form bodies.
+func ExpandResponse(opts *RequestOptions, response string) (*RequestOptions, error) {
+	var (
+		data map[string]any
+		errs []error
+	)
+
+	if err := json.Unmarshal([]byte(response), &data); err != nil {
+		return nil, fmt.Errorf("%w: response not a JSON object: %w", ErrRequestTemplate, err)
+	}
+
+	expand := func(s string, escape func(string) string) string {
+		result, err := utils.ExpandPlaceholders(s, []string{"response"}, func(_, key string) (string, error) {
+			if key == "" {
+				return "", fmt.Errorf("%w: no key given for response", utils.ErrObjectKeyNotFound)
+			}
+
+			value, err := utils.GetStringAtKey(data, key)
+			if err != nil {
+				return "", err
+			}
+
+			return escape(value), nil
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", ErrRequestTemplate, err))
+		}
+
+		return result
+	}
+
+	verbatim := func(s string) string { return s }
+
+	result := *opts
+	result.Path = expand(opts.Path, url.PathEscape)
+	result.Body = expand(opts.Body, bodyEscape(opts.ContentType))
+	result.QueryParams = maps.Clone(opts.QueryParams)
+	result.Headers = maps.Clone(opts.Headers)
+
+	for key, value := range result.QueryParams {
+		result.QueryParams[key] = expand(value, verbatim)
+	}
+
+	for name, value := range result.Headers {
+		result.Headers[name] = expand(value, verbatim)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return &result, nil
+}
+
+// bodyEscape returns the escape function for values inserted into a body of the
+// given content type. Bodies without content type are sent as JSON.
+func bodyEscape(contentType string) func(string) string {
+	mediaType := restclient.ContentTypeJSON
+
+	if contentType != "" {
+		mediaType, _, _ = mime.ParseMediaType(contentType)
+	}
+
+	switch {
+	case mediaType == restclient.ContentTypeJSON, mediaType == "text/json", strings.HasSuffix(mediaType, "+json"):
+		return escapeJSONString
+	case mediaType == restclient.ContentTypeForm:
+		return url.QueryEscape
+	}
+
+	return func(s string) string { return s }
+}
+
+// escapeJSONString escapes the given value for the content of a JSON string.
+func escapeJSONString(s string) string {
+	b, _ := json.Marshal(s)
+
+	return string(b[1 : len(b)-1])
+}
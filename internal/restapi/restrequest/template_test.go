@@ -0,0 +1,105 @@
+package restrequest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandResponse(t *testing.T) {
+	response := `{"id": 42, "session": {"token": "abc/def", "user": "foo bar"}}`
+
+	tests := []struct {
+		name     string
+		opts     *RequestOptions
+		response string
+		want     *RequestOptions
+		wantErr  error
+	}{
+		{
+			name: "no placeholders",
+			opts: &RequestOptions{Method: "DELETE", Path: "/session"},
+			want: &RequestOptions{Method: "DELETE", Path: "/session"},
+		},
+		{
+			name: "placeholders",
+			opts: &RequestOptions{
+				Method:      "POST",
+				Path:        "/sessions/{response.session/token}/users/{response.id}",
+				QueryParams: map[string]string{"user": "{response.session/user}"},
+				Headers:     map[string]string{"Authorization": "Bearer {response.session/token}"},
+				Body:        `{"user": "{response.session/user}"}`,
+			},
+			want: &RequestOptions{
+				Method:      "POST",
+				Path:        "/sessions/abc%2Fdef/users/42",
+				QueryParams: map[string]string{"user": "foo bar"},
+				Headers:     map[string]string{"Authorization": "Bearer abc/def"},
+				Body:        `{"user": "foo bar"}`,
+			},
+		},
+		{
+			name: "json body",
+			opts: &RequestOptions{
+				Body: `{"user": "{response.session/user}"}`,
+			},
+			response: `{"session": {"user": "foo \"bar\" \\ baz"}}`,
+			want: &RequestOptions{
+				Body: `{"user": "foo \"bar\" \\ baz"}`,
+			},
+		},
+		{
+			name: "form body",
+			opts: &RequestOptions{
+				ContentType: "application/x-www-form-urlencoded",
+				Body:        "user={response.session/user}",
+			},
+			response: `{"session": {"user": "foo \"bar\"&x=1"}}`,
+			want: &RequestOptions{
+				ContentType: "application/x-www-form-urlencoded",
+				Body:        "user=foo+%22bar%22%26x%3D1",
+			},
+		},
+		{
+			name: "text body",
+			opts: &RequestOptions{
+				ContentType: "text/plain",
+				Body:        "user={response.session/user}",
+			},
+			response: `{"session": {"user": "foo \"bar\""}}`,
+			want: &RequestOptions{
+				ContentType: "text/plain",
+				Body:        `user=foo "bar"`,
+			},
+		},
+		{
+			name:    "missing key",
+			opts:    &RequestOptions{Path: "/sessions/{response.session/missing}"},
+			wantErr: ErrRequestTemplate,
+		},
+		{
+			name:     "invalid response",
+			opts:     &RequestOptions{Path: "/sessions"},
+			response: `["abc"]`,
+			wantErr:  ErrRequestTemplate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.response == "" {
+				tt.response = response
+			}
+
+			got, err := ExpandResponse(tt.opts, tt.response)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
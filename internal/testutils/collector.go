@@ -0,0 +1,91 @@
+package testutils
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// TraceCollector is a local OTLP/HTTP collector that records the received
+// spans. It is intended to be used in test cases to verify exported traces.
+type TraceCollector struct {
+	// Endpoint is the URL of the collector.
+	Endpoint string
+
+	mu    sync.Mutex
+	spans []*tracepb.Span
+}
+
+// NewTraceCollector starts a local OTLP/HTTP collector, which is stopped
+// when the test finishes.
+func NewTraceCollector(t *testing.T) *TraceCollector {
+	t.Helper()
+
+	collector := &TraceCollector{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req collectortrace.ExportTraceServiceRequest
+
+		body, err := io.ReadAll(r.Body)
+		if err == nil {
+			err = proto.Unmarshal(body, &req)
+		}
+
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		collector.mu.Lock()
+		defer collector.mu.Unlock()
+
+		for _, resourceSpans := range req.GetResourceSpans() {
+			for _, scopeSpans := range resourceSpans.GetScopeSpans() {
+				collector.spans = append(collector.spans, scopeSpans.GetSpans()...)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+
+	t.Cleanup(server.Close)
+
+	collector.Endpoint = server.URL
+
+	return collector
+}
+
+// Spans returns the received spans mapped by their name.
+func (c *TraceCollector) Spans() map[string]*tracepb.Span {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	spans := make(map[string]*tracepb.Span, len(c.spans))
+
+	for _, span := range c.spans {
+		spans[span.GetName()] = span
+	}
+
+	return spans
+}
+
+// AssertChildSpan asserts that the spans contain the parent and the child span
+// and that the child span is a direct child of the parent span.
+func AssertChildSpan(t *testing.T, spans map[string]*tracepb.Span, parent, child string) bool {
+	t.Helper()
+
+	if !assert.Contains(t, spans, parent) || !assert.Contains(t, spans, child) {
+		return false
+	}
+
+	return assert.Equal(t, spans[parent].GetSpanId(), spans[child].GetParentSpanId(),
+		"span '%s' is not a child of '%s'", child, parent)
+}
@@ -0,0 +1,51 @@
+// Package mockclient provides a RestClient for test cases, which is separated
+// from the testutils package to be usable without import cycles.
+package mockclient
+
+import (
+	"testing"
+
+	"github.com/thegeeklab/terraform-provider-restapi/internal/restapi/restclient"
+	"github.com/thegeeklab/terraform-provider-restapi/internal/testutils"
+
+	"github.com/jarcoal/httpmock"
+)
+
+// New creates a RestClient with the given options, whose requests are
+// intercepted by httpmock until the test finishes. The endpoint defaults to
+// `https://restapi.local/` and the rate limit to 100 requests per second.
+func New(t *testing.T, opts *restclient.ClientOptions) *restclient.RestClient {
+	t.Helper()
+
+	if opts.Endpoint == "" {
+		opts.Endpoint = "https://restapi.local/"
+	}
+
+	if opts.RateLimit == 0 {
+		opts.RateLimit = 100
+	}
+
+	client, err := restclient.New(t.Context(), opts)
+	if err != nil {
+		t.Fatalf("client creation failed: %v", err)
+	}
+
+	httpmock.ActivateNonDefault(client.HTTPClient)
+
+	t.Cleanup(func() {
+		httpmock.DeactivateAndReset()
+	})
+
+	return client
+}
+
+// NewTraced creates a RestClient like New, which exports its traces to a local
+// collector that is returned alongside.
+func NewTraced(t *testing.T, opts *restclient.ClientOptions) (*restclient.RestClient, *testutils.TraceCollector) {
+	t.Helper()
+
+	collector := testutils.NewTraceCollector(t)
+	opts.Tracing = &restclient.TracingOptions{Endpoint: collector.Endpoint}
+
+	return New(t, opts), collector
+}
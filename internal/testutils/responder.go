@@ -0,0 +1,34 @@
+package testutils
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// RegisterRecordingResponder registers an httpmock responder for the given
+// method and URL, which decodes the JSON request bodies into requests and
+// replies with the given responses in order. Requests exceeding the responses
+// are answered with an internal server error.
+func RegisterRecordingResponder[T any](t *testing.T, method, url string, requests *[]T, responses ...string) {
+	t.Helper()
+
+	httpmock.RegisterResponder(method, url, func(req *http.Request) (*http.Response, error) {
+		var body T
+
+		b, _ := io.ReadAll(req.Body)
+		assert.NoError(t, json.Unmarshal(b, &body))
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+
+		*requests = append(*requests, body)
+		if len(*requests) > len(responses) {
+			return httpmock.NewStringResponse(http.StatusInternalServerError, "unexpected request"), nil
+		}
+
+		return httpmock.NewStringResponse(http.StatusOK, responses[len(*requests)-1]), nil
+	})
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+// placeholderPattern matches the placeholders `{<scope>}` and `{<scope>.<key>}`.
+var placeholderPattern = regexp.MustCompile(`\{([a-z]+)(?:\.([^{}]+))?\}`)
+
+// ExpandPlaceholders replaces the placeholders `{<scope>}` and `{<scope>.<key>}`
+// of the given scopes in s by the value returned by resolve. Placeholders of
+// other scopes are kept verbatim. The errors of all placeholders that can not
+// be resolved are joined.
+func ExpandPlaceholders(s string, scopes []string, resolve func(scope, key string) (string, error)) (string, error) {
+	var errs []error
+
+	result := placeholderPattern.ReplaceAllStringFunc(s, func(placeholder string) string {
+		match := placeholderPattern.FindStringSubmatch(placeholder)
+		scope, key := match[1], match[2]
+
+		if !slices.Contains(scopes, scope) {
+			return placeholder
+		}
+
+		value, err := resolve(scope, key)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("placeholder '%s': %w", placeholder, err))
+
+			return placeholder
+		}
+
+		return value
+	})
+
+	return result, errors.Join(errs...)
+}
+
+// ParsePlaceholder returns the scope and key of s if s consists of a single
+// placeholder `{<scope>}` or `{<scope>.<key>}`.
+func ParsePlaceholder(s string) (string, string, bool) {
+	match := placeholderPattern.FindStringSubmatch(s)
+	if match == nil || match[0] != s {
+		return "", "", false
+	}
+
+	return match[1], match[2], true
+}
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandPlaceholders(t *testing.T) {
+	errResolve := errors.New("resolve failed")
+
+	resolve := func(scope, key string) (string, error) {
+		if key == "missing" {
+			return "", errResolve
+		}
+
+		return scope + ":" + key, nil
+	}
+
+	tests := []struct {
+		name    string
+		s       string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "scope",
+			s:    "/things/{id}",
+			want: "/things/id:",
+		},
+		{
+			name: "scope with key",
+			s:    "/projects/{data.project/id}/things/{id}",
+			want: "/projects/data:project/id/things/id:",
+		},
+		{
+			name: "other scope",
+			s:    "/things/{search_value}/{other.key}",
+			want: "/things/{search_value}/{other.key}",
+		},
+		{
+			name:    "resolve error",
+			s:       "/things/{data.missing}",
+			wantErr: errResolve,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandPlaceholders(tt.s, []string{"id", "data"}, resolve)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.ErrorContains(t, err, "placeholder '{data.missing}'")
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParsePlaceholder(t *testing.T) {
+	tests := []struct {
+		s         string
+		wantScope string
+		wantKey   string
+		wantOK    bool
+	}{
+		{s: "{id}", wantScope: "id", wantOK: true},
+		{s: "{data.owner/id}", wantScope: "data", wantKey: "owner/id", wantOK: true},
+		{s: "name-{id}"},
+		{s: "{id}-{data.name}"},
+		{s: "id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			scope, key, ok := ParsePlaceholder(tt.s)
+
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantScope, scope)
+			assert.Equal(t, tt.wantKey, key)
+		})
+	}
+}
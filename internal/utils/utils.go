@@ -4,16 +4,29 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"net/url"
 	"os"
+	"reflect"
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 )
 
+// ImportIDSeparator separates the collection path from an arbitrary object ID
+// in import IDs, e.g. `/path/to/collection|id/with/slashes`.
+const ImportIDSeparator = "|"
+
+// RedactedValue replaces the values of sensitive keys, see RedactKeys.
+const RedactedValue = "(redacted)"
+
 var (
 	ErrInvalidObjectType = errors.New("invalid object type")
 	ErrObjectKeyNotFound = errors.New("key not found in object")
 	ErrJSONMarshal       = errors.New("can not parse json")
 	ErrInvalidImportPath = errors.New("")
+	ErrInvalidImportID   = errors.New("invalid import id")
 )
 
 // GetStringAtKey returns the string value at the given slash-delimited path
@@ -29,7 +42,11 @@ func GetStringAtKey(data map[string]any, path string) (string, error) {
 	switch v := res.(type) {
 	case string:
 		return v, nil
-	case float64, float32, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
 		return fmt.Sprintf("%v", v), nil
 	default:
 		return "", fmt.Errorf("%w: path '%s': '%T'", ErrInvalidObjectType, path, res)
@@ -75,14 +92,7 @@ func GetObjectAtKey(data map[string]any, path string) (any, error) {
 		if tmp, ok := data[part].(map[string]any); ok {
 			data = tmp
 		} else if tmp, ok := data[part].([]any); ok {
-			mapString := make(map[string]any)
-
-			for key, value := range tmp {
-				strKey := fmt.Sprintf("%v", key)
-				mapString[strKey] = value
-			}
-
-			data = mapString
+			data = IndexList(tmp)
 		} else {
 			return nil, fmt.Errorf("%w: object '%s': not a map, please check the path", ErrInvalidObjectType, seen)
 		}
@@ -97,6 +107,18 @@ func GetObjectAtKey(data map[string]any, path string) (any, error) {
 	return data[part], nil
 }
 
+// IndexList returns the elements of the list as map keyed by their index,
+// to address them in slash-delimited paths, e.g. `0/name`.
+func IndexList(list []any) map[string]any {
+	data := make(map[string]any, len(list))
+
+	for i, value := range list {
+		data[strconv.Itoa(i)] = value
+	}
+
+	return data
+}
+
 // GetKeys returns a slice containing all the keys in the given hash map.
 func GetKeys(hash map[string]any) []string {
 	keys := make([]string, 0)
@@ -152,8 +174,23 @@ func SanitizePath(path string) string {
 }
 
 // ParseImportPath parses a Restobject import path string into its object ID
-// and path components.
+// and path components. The object ID is either the last path segment or,
+// if the ImportIDSeparator is present, everything after the separator. The
+// latter is taken verbatim and may contain slashes or other special characters.
 func ParseImportPath(id string) (string, string, error) {
+	if path, objectID, ok := strings.Cut(id, ImportIDSeparator); ok {
+		path = SanitizePath(path)
+
+		if path == "" || objectID == "" {
+			err := fmt.Errorf("%w: api_object '%s': expected format /path/to/collection%sobject_id not met",
+				ErrInvalidImportPath, id, ImportIDSeparator)
+
+			return "", "", err
+		}
+
+		return objectID, fmt.Sprintf("/%s", path), nil
+	}
+
 	rawID := fmt.Sprintf("/%s", SanitizePath(id))
 	n := strings.LastIndex(rawID, "/")
 
@@ -169,6 +206,135 @@ func ParseImportPath(id string) (string, string, error) {
 	return id, path, nil
 }
 
+// ParseIDComponents parses key/value pairs in the format `key1=value1&key2=value2`.
+// Values may be URL-encoded. It returns the keys and values in their original
+// order, and false if the ID is not in this format.
+func ParseIDComponents(id string) ([]string, []string, bool) {
+	if !strings.Contains(id, "=") {
+		return nil, nil, false
+	}
+
+	pairs := strings.Split(id, "&")
+	keys := make([]string, 0, len(pairs))
+	values := make([]string, 0, len(pairs))
+
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, nil, false
+		}
+
+		value, err := url.QueryUnescape(value)
+		if err != nil {
+			return nil, nil, false
+		}
+
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+
+	return keys, values, true
+}
+
+// ImportOptions holds the options of an extended import ID.
+type ImportOptions struct {
+	ID           string              `json:"id"`
+	Path         string              `json:"path"`
+	ReadPath     string              `json:"read_path"`
+	IDAttribute  string              `json:"id_attribute"`
+	IDAttributes []string            `json:"id_attributes"`
+	IDSeparator  string              `json:"id_separator"`
+	QueryString  string              `json:"query_string"`
+	ReadSearch   *ImportSearchOption `json:"read_search"`
+	DataKeys     []string            `json:"data_keys"`
+}
+
+// ImportSearchOption holds the read_search options of an extended import ID.
+type ImportSearchOption struct {
+	SearchKey   string `json:"search_key"`
+	SearchValue string `json:"search_value"`
+	ResultKey   string `json:"result_key"`
+	QueryString string `json:"query_string"`
+}
+
+// ParseImportOptions parses an extended import ID, which is either a JSON object
+// or key/value pairs in the format `path=/api/objects&id=123`. Values of key/value
+// pairs may be URL-encoded, the read_search options are passed as `search_key`,
+// `search_value`, `result_key` and `search_query_string` and the data keys and
+// id attributes as comma-separated lists. It returns false if the ID is not in an extended format.
+func ParseImportOptions(id string) (*ImportOptions, bool, error) {
+	opts := &ImportOptions{}
+
+	if strings.HasPrefix(strings.TrimSpace(id), "{") {
+		decoder := json.NewDecoder(strings.NewReader(id))
+		decoder.DisallowUnknownFields()
+
+		if err := decoder.Decode(opts); err != nil {
+			return nil, true, fmt.Errorf("%w: %w", ErrInvalidImportID, err)
+		}
+
+		return opts, true, validateImportOptions(opts)
+	}
+
+	keys, values, ok := ParseIDComponents(id)
+	if !ok || !slices.Contains(keys, "path") {
+		return nil, false, nil
+	}
+
+	search := &ImportSearchOption{}
+
+	for i, key := range keys {
+		value := values[i]
+
+		switch key {
+		case "id":
+			opts.ID = value
+		case "path":
+			opts.Path = value
+		case "read_path":
+			opts.ReadPath = value
+		case "id_attribute":
+			opts.IDAttribute = value
+		case "id_attributes":
+			opts.IDAttributes = strings.Split(value, ",")
+		case "id_separator":
+			opts.IDSeparator = value
+		case "query_string":
+			opts.QueryString = value
+		case "search_key":
+			search.SearchKey = value
+		case "search_value":
+			search.SearchValue = value
+		case "result_key":
+			search.ResultKey = value
+		case "search_query_string":
+			search.QueryString = value
+		case "data_keys":
+			opts.DataKeys = strings.Split(value, ",")
+		default:
+			return nil, true, fmt.Errorf("%w: unknown key '%s'", ErrInvalidImportID, key)
+		}
+	}
+
+	if *search != (ImportSearchOption{}) {
+		opts.ReadSearch = search
+	}
+
+	return opts, true, validateImportOptions(opts)
+}
+
+func validateImportOptions(opts *ImportOptions) error {
+	if SanitizePath(opts.Path) == "" {
+		return fmt.Errorf("%w: path not set", ErrInvalidImportID)
+	}
+
+	if opts.ID == "" {
+		return fmt.Errorf("%w: id not set", ErrInvalidImportID)
+	}
+
+	return nil
+}
+
 // IntersectMaps takes two maps and returns a new map containing only the
 // keys and values that exist in both input maps. For keys that exist in
 // both maps but have different value types, the value from map2 is used.
@@ -196,6 +362,293 @@ func IntersectMaps(map1, map2 map[string]any) map[string]any {
 	return result
 }
 
+// MatchScalarTypes returns a copy of map2, where string values are replaced by
+// the values of map1 at the same key, if these are numbers or booleans with the
+// same string form. Nested maps and arrays of the same length are matched
+// recursively. It allows to compare data with untyped values, e.g. of XML documents.
+func MatchScalarTypes(map1, map2 map[string]any) map[string]any {
+	result := make(map[string]any, len(map2))
+
+	for k, v2 := range map2 {
+		result[k] = matchScalarType(map1[k], v2)
+	}
+
+	return result
+}
+
+func matchScalarType(v1, v2 any) any {
+	switch v := v2.(type) {
+	case map[string]any:
+		if vMap, ok := v1.(map[string]any); ok {
+			return MatchScalarTypes(vMap, v)
+		}
+	case []any:
+		if items, ok := v1.([]any); ok && len(items) == len(v) {
+			result := make([]any, len(v))
+
+			for i := range v {
+				result[i] = matchScalarType(items[i], v[i])
+			}
+
+			return result
+		}
+	case string:
+		switch scalar := v1.(type) {
+		case float64:
+			if strconv.FormatFloat(scalar, 'f', -1, 64) == v {
+				return scalar
+			}
+		case bool:
+			if strconv.FormatBool(scalar) == v {
+				return scalar
+			}
+		}
+	}
+
+	return v2
+}
+
+// MergeMaps takes two maps and returns a new map containing the keys and
+// values of both input maps. For keys that exist in both maps, the value from
+// map2 is used. Nested maps are merged recursively.
+func MergeMaps(map1, map2 map[string]any) map[string]any {
+	result := make(map[string]any, len(map1))
+
+	maps.Copy(result, map1)
+
+	for k, v2 := range map2 {
+		vMap, vMapOk := result[k].(map[string]any)
+		v2Map, v2MapOk := v2.(map[string]any)
+
+		if vMapOk && v2MapOk {
+			result[k] = MergeMaps(vMap, v2Map)
+
+			continue
+		}
+
+		result[k] = v2
+	}
+
+	return result
+}
+
+// MergePatch applies a JSON merge patch as defined in RFC 7386 to the target
+// map and returns the result as new map. Keys with null values in the patch
+// are removed from the target, nested maps are patched recursively and all
+// other values replace the target values.
+func MergePatch(target, patch map[string]any) map[string]any {
+	result := make(map[string]any, len(target))
+
+	maps.Copy(result, target)
+
+	for k, v := range patch {
+		if v == nil {
+			delete(result, k)
+
+			continue
+		}
+
+		vMap, ok := v.(map[string]any)
+		if !ok {
+			result[k] = v
+
+			continue
+		}
+
+		targetMap, ok := result[k].(map[string]any)
+		if !ok {
+			targetMap = make(map[string]any)
+		}
+
+		result[k] = MergePatch(targetMap, vMap)
+	}
+
+	return result
+}
+
+// DiffMaps returns a JSON merge patch as defined in RFC 7386 that transforms
+// map1 into map2, so that MergePatch(map1, DiffMaps(map1, map2)) equals map2.
+// Keys that are missing in map2 are set to null, and nested maps are compared
+// recursively.
+func DiffMaps(map1, map2 map[string]any) map[string]any {
+	result := make(map[string]any)
+
+	for k := range map1 {
+		if _, ok := map2[k]; !ok {
+			result[k] = nil
+		}
+	}
+
+	for k, v2 := range map2 {
+		v, ok := map1[k]
+		if ok && reflect.DeepEqual(v, v2) {
+			continue
+		}
+
+		vMap, vMapOk := v.(map[string]any)
+		v2Map, v2MapOk := v2.(map[string]any)
+
+		if vMapOk && v2MapOk {
+			result[k] = DiffMaps(vMap, v2Map)
+
+			continue
+		}
+
+		result[k] = v2
+	}
+
+	return result
+}
+
+// ExcludeMaps takes two maps and returns a new map containing the keys and
+// values of map1 that do not exist in map2. For keys that hold maps in both
+// input maps, the nested maps are excluded recursively.
+func ExcludeMaps(map1, map2 map[string]any) map[string]any {
+	result := make(map[string]any, len(map1))
+
+	for k, v := range map1 {
+		v2, ok := map2[k]
+		if !ok {
+			result[k] = v
+
+			continue
+		}
+
+		vMap, vMapOk := v.(map[string]any)
+		v2Map, v2MapOk := v2.(map[string]any)
+
+		if vMapOk && v2MapOk {
+			result[k] = ExcludeMaps(vMap, v2Map)
+		}
+	}
+
+	return result
+}
+
+// ClearMaps returns a copy of the map with the same keys but without the
+// values. Nested maps are cleared recursively, all other values are set to nil.
+func ClearMaps(data map[string]any) map[string]any {
+	result := make(map[string]any, len(data))
+
+	for k, v := range data {
+		if vMap, ok := v.(map[string]any); ok {
+			result[k] = ClearMaps(vMap)
+
+			continue
+		}
+
+		result[k] = nil
+	}
+
+	return result
+}
+
+// RedactKeys returns a copy of the map with the values at the given
+// slash-delimited key paths replaced by RedactedValue, and the replaced values
+// mapped by their key path. The path segment `*` matches all keys of a map or
+// all elements of an array. Key paths that do not exist are ignored.
+func RedactKeys(data map[string]any, keys []string) (map[string]any, map[string]any) {
+	values := make(map[string]any)
+
+	if data == nil {
+		return nil, values
+	}
+
+	//nolint:forcetypeassert
+	return redactKeys(data, keys, values).(map[string]any), values
+}
+
+// RedactJSONString redacts the values at the given key paths from a JSON
+// string like RedactKeys, e.g. to log request and response bodies. The key
+// paths are applied to all elements of JSON arrays. Returns RedactedValue if
+// keys are given but the data is not valid JSON.
+func RedactJSONString(data string, keys []string) string {
+	if len(keys) == 0 || data == "" {
+		return data
+	}
+
+	var obj any
+
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&obj); err != nil {
+		return RedactedValue
+	}
+
+	if _, ok := obj.([]any); ok {
+		elementKeys := make([]string, 0, len(keys))
+
+		for _, key := range keys {
+			elementKeys = append(elementKeys, "*/"+key)
+		}
+
+		keys = elementKeys
+	}
+
+	result, err := json.Marshal(redactKeys(obj, keys, make(map[string]any)))
+	if err != nil {
+		return RedactedValue
+	}
+
+	return string(result)
+}
+
+// redactKeys replaces the values at the key paths of data like RedactKeys.
+func redactKeys(data any, keys []string, values map[string]any) any {
+	for _, key := range keys {
+		parts := slices.DeleteFunc(strings.Split(key, "/"), func(part string) bool {
+			return part == ""
+		})
+
+		if len(parts) > 0 {
+			data = redactKey(data, parts, "", values)
+		}
+	}
+
+	return data
+}
+
+// redactKey replaces the value at the key path parts of data. The path of
+// data is used to map the replaced values.
+func redactKey(data any, parts []string, path string, values map[string]any) any {
+	if len(parts) == 0 {
+		values[path] = data
+
+		return RedactedValue
+	}
+
+	key := parts[0]
+	join := func(k string) string {
+		return strings.TrimPrefix(path+"/"+k, "/")
+	}
+
+	switch v := data.(type) {
+	case map[string]any:
+		result := maps.Clone(v)
+
+		for k, value := range v {
+			if key == "*" || key == k {
+				result[k] = redactKey(value, parts[1:], join(k), values)
+			}
+		}
+
+		return result
+	case []any:
+		result := slices.Clone(v)
+
+		for i, value := range v {
+			if k := strconv.Itoa(i); key == "*" || key == k {
+				result[i] = redactKey(value, parts[1:], join(k), values)
+			}
+		}
+
+		return result
+	default:
+		return data
+	}
+}
+
 // FilterJSONString filters keys from a JSON string. It takes a JSON string, a
 // list of keys to filter, and a boolean indicating whether to include or
 // exclude those keys. It returns the filtered JSON object, the filtered JSON
@@ -13,6 +13,7 @@ func TestGetStringAtKey(t *testing.T) {
 	data := MapAny{
 		"foo":  "bar",
 		"baz":  123,
+		"big":  float64(123456789012),
 		"bool": true,
 	}
 
@@ -34,6 +35,12 @@ func TestGetStringAtKey(t *testing.T) {
 			want:    "123",
 			wantErr: nil,
 		},
+		{
+			name:    "large float value",
+			key:     "big",
+			want:    "123456789012",
+			wantErr: nil,
+		},
 		{
 			name:    "bool value",
 			key:     "bool",
@@ -238,6 +245,22 @@ func TestParseImportPath(t *testing.T) {
 			id:      "123",
 			wantErr: assert.AnError,
 		},
+		{
+			name:     "separated id",
+			id:       "/api/v1/objects/|id/with/slashes?and#more",
+			wantID:   "id/with/slashes?and#more",
+			wantPath: "/api/v1/objects",
+		},
+		{
+			name:    "separated id without path",
+			id:      "|123",
+			wantErr: assert.AnError,
+		},
+		{
+			name:    "separated id without id",
+			id:      "/api/v1/objects|",
+			wantErr: assert.AnError,
+		},
 	}
 
 	for _, tt := range tests {
@@ -256,6 +279,172 @@ func TestParseImportPath(t *testing.T) {
 	}
 }
 
+func TestParseIDComponents(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		wantKeys   []string
+		wantValues []string
+		wantOk     bool
+	}{
+		{
+			name:       "composite id",
+			id:         "org=acme&slug=devs",
+			wantKeys:   []string{"org", "slug"},
+			wantValues: []string{"acme", "devs"},
+			wantOk:     true,
+		},
+		{
+			name:       "escaped values",
+			id:         "org=acme%26co&slug=dev+ops",
+			wantKeys:   []string{"org", "slug"},
+			wantValues: []string{"acme&co", "dev ops"},
+			wantOk:     true,
+		},
+		{
+			name:   "plain id",
+			id:     "123",
+			wantOk: false,
+		},
+		{
+			name:   "missing key",
+			id:     "org=acme&devs",
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, values, ok := ParseIDComponents(tt.id)
+
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantKeys, keys)
+			assert.Equal(t, tt.wantValues, values)
+		})
+	}
+}
+
+func TestParseImportOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		want    *ImportOptions
+		wantOk  bool
+		wantErr error
+	}{
+		{
+			name:   "plain path",
+			id:     "/api/v1/objects/123",
+			wantOk: false,
+		},
+		{
+			name:   "key value id",
+			id:     "/api/v1/teams/org=acme&slug=devs",
+			wantOk: false,
+		},
+		{
+			name:   "base64 id",
+			id:     "/api/v1/objects/abc==",
+			wantOk: false,
+		},
+		{
+			name: "key value pairs",
+			id: "path=/api/v1/objects&id=123&read_path=/api/v1/objects/{id}/details" +
+				"&id_attribute=uuid&query_string=expand%3Dall%26limit%3D1&data_keys=name,size",
+			want: &ImportOptions{
+				ID:          "123",
+				Path:        "/api/v1/objects",
+				ReadPath:    "/api/v1/objects/{id}/details",
+				IDAttribute: "uuid",
+				QueryString: "expand=all&limit=1",
+				DataKeys:    []string{"name", "size"},
+			},
+			wantOk: true,
+		},
+		{
+			name: "key value pairs with read search",
+			id:   "path=/api/v1/objects&id=123&search_key=name&search_value=foo&result_key=items",
+			want: &ImportOptions{
+				ID:   "123",
+				Path: "/api/v1/objects",
+				ReadSearch: &ImportSearchOption{
+					SearchKey:   "name",
+					SearchValue: "foo",
+					ResultKey:   "items",
+				},
+			},
+			wantOk: true,
+		},
+		{
+			name: "key value pairs with composite id",
+			id:   "path=/api/v1/teams&id=acme%7Cdevs&id_attributes=org,owner/login&id_separator=%7C",
+			want: &ImportOptions{
+				ID:           "acme|devs",
+				Path:         "/api/v1/teams",
+				IDAttributes: []string{"org", "owner/login"},
+				IDSeparator:  "|",
+			},
+			wantOk: true,
+		},
+		{
+			name: "json",
+			id: `{"path": "/api/v1/objects", "id": "a/b", "read_search": {"search_key": "name", ` +
+				`"search_value": "foo"}, "data_keys": ["name"]}`,
+			want: &ImportOptions{
+				ID:   "a/b",
+				Path: "/api/v1/objects",
+				ReadSearch: &ImportSearchOption{
+					SearchKey:   "name",
+					SearchValue: "foo",
+				},
+				DataKeys: []string{"name"},
+			},
+			wantOk: true,
+		},
+		{
+			name:    "unknown key",
+			id:      "path=/api/v1/objects&id=123&foo=bar",
+			wantOk:  true,
+			wantErr: ErrInvalidImportID,
+		},
+		{
+			name:    "unknown json key",
+			id:      `{"path": "/api/v1/objects", "id": "123", "foo": "bar"}`,
+			wantOk:  true,
+			wantErr: ErrInvalidImportID,
+		},
+		{
+			name:    "missing id",
+			id:      `{"path": "/api/v1/objects"}`,
+			wantOk:  true,
+			wantErr: ErrInvalidImportID,
+		},
+		{
+			name:    "missing path",
+			id:      `{"id": "123"}`,
+			wantOk:  true,
+			wantErr: ErrInvalidImportID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := ParseImportOptions(tt.id)
+
+			assert.Equal(t, tt.wantOk, ok)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestIntersectMaps(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -312,6 +501,315 @@ func TestIntersectMaps(t *testing.T) {
 	}
 }
 
+func TestMatchScalarTypes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		map1     MapAny
+		map2     MapAny
+		expected MapAny
+	}{
+		{
+			name:     "matching scalars",
+			map1:     MapAny{"count": float64(10), "size": float64(1000000), "enabled": true},
+			map2:     MapAny{"count": "10", "size": "1000000", "enabled": "true"},
+			expected: MapAny{"count": float64(10), "size": float64(1000000), "enabled": true},
+		},
+		{
+			name:     "differing scalars",
+			map1:     MapAny{"count": float64(10), "enabled": true, "version": float64(1)},
+			map2:     MapAny{"count": "11", "enabled": "false", "version": "1.0"},
+			expected: MapAny{"count": "11", "enabled": "false", "version": "1.0"},
+		},
+		{
+			name:     "string values",
+			map1:     MapAny{"name": "10", "other": float64(1)},
+			map2:     MapAny{"name": "10", "other": float64(2), "new": "3"},
+			expected: MapAny{"name": "10", "other": float64(2), "new": "3"},
+		},
+		{
+			name:     "nested",
+			map1:     MapAny{"meta": MapAny{"revision": float64(3)}, "tags": []any{float64(1), float64(2)}},
+			map2:     MapAny{"meta": MapAny{"revision": "3"}, "tags": []any{"1", "2"}},
+			expected: MapAny{"meta": MapAny{"revision": float64(3)}, "tags": []any{float64(1), float64(2)}},
+		},
+		{
+			name:     "arrays of different length",
+			map1:     MapAny{"tags": []any{float64(1)}},
+			map2:     MapAny{"tags": []any{"1", "2"}},
+			expected: MapAny{"tags": []any{"1", "2"}},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			result := MatchScalarTypes(tt.map1, tt.map2)
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %v but got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMergeMaps(t *testing.T) {
+	testCases := []struct {
+		name     string
+		map1     MapAny
+		map2     MapAny
+		expected MapAny
+	}{
+		{
+			name:     "both maps empty",
+			map1:     MapAny{},
+			map2:     MapAny{},
+			expected: MapAny{},
+		},
+		{
+			name:     "disjoint keys",
+			map1:     MapAny{"key1": "value1"},
+			map2:     MapAny{"key2": "value2"},
+			expected: MapAny{"key1": "value1", "key2": "value2"},
+		},
+		{
+			name:     "overwrite values",
+			map1:     MapAny{"key1": "value1", "key2": []any{"a"}},
+			map2:     MapAny{"key1": nil, "key2": []any{"b"}},
+			expected: MapAny{"key1": nil, "key2": []any{"b"}},
+		},
+		{
+			name:     "merge nested",
+			map1:     MapAny{"outside": MapAny{"keep": "a", "change": "a"}, "replace": MapAny{"x": "y"}},
+			map2:     MapAny{"outside": MapAny{"change": "b", "add": "c"}, "replace": "z"},
+			expected: MapAny{"outside": MapAny{"keep": "a", "change": "b", "add": "c"}, "replace": "z"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			result := MergeMaps(tt.map1, tt.map2)
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %v but got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		target   MapAny
+		patch    MapAny
+		expected MapAny
+	}{
+		{
+			name:     "replace and add",
+			target:   MapAny{"a": "b", "c": []any{"d"}},
+			patch:    MapAny{"a": "x", "c": []any{"y"}, "e": true},
+			expected: MapAny{"a": "x", "c": []any{"y"}, "e": true},
+		},
+		{
+			name:     "remove with null",
+			target:   MapAny{"a": "b", "c": "d"},
+			patch:    MapAny{"a": nil, "missing": nil},
+			expected: MapAny{"c": "d"},
+		},
+		{
+			name:     "patch nested",
+			target:   MapAny{"title": "Goodbye!", "author": MapAny{"givenName": "John", "familyName": "Doe"}},
+			patch:    MapAny{"title": "Hello!", "author": MapAny{"familyName": nil}, "phone": MapAny{"home": "1"}},
+			expected: MapAny{"title": "Hello!", "author": MapAny{"givenName": "John"}, "phone": MapAny{"home": "1"}},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			result := MergePatch(tt.target, tt.patch)
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %v but got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestDiffMaps(t *testing.T) {
+	testCases := []struct {
+		name     string
+		map1     MapAny
+		map2     MapAny
+		expected MapAny
+	}{
+		{
+			name:     "equal",
+			map1:     MapAny{"a": "b", "c": MapAny{"d": []any{"e"}}},
+			map2:     MapAny{"a": "b", "c": MapAny{"d": []any{"e"}}},
+			expected: MapAny{},
+		},
+		{
+			name:     "changed, added and removed",
+			map1:     MapAny{"a": "b", "c": "d", "list": []any{"x"}},
+			map2:     MapAny{"a": "x", "e": "f", "list": []any{"x", "y"}},
+			expected: MapAny{"a": "x", "c": nil, "e": "f", "list": []any{"x", "y"}},
+		},
+		{
+			name:     "nested",
+			map1:     MapAny{"outside": MapAny{"keep": "a", "change": "a", "remove": "a"}, "obj": "x"},
+			map2:     MapAny{"outside": MapAny{"keep": "a", "change": "b"}, "obj": MapAny{"y": "z"}},
+			expected: MapAny{"outside": MapAny{"change": "b", "remove": nil}, "obj": MapAny{"y": "z"}},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			result := DiffMaps(tt.map1, tt.map2)
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %v but got %v", tt.expected, result)
+			}
+
+			if patched := MergePatch(tt.map1, result); !reflect.DeepEqual(patched, tt.map2) {
+				t.Errorf("expected patched %v but got %v", tt.map2, patched)
+			}
+		})
+	}
+}
+
+func TestExcludeMaps(t *testing.T) {
+	testCases := []struct {
+		name     string
+		map1     MapAny
+		map2     MapAny
+		expected MapAny
+	}{
+		{
+			name:     "nothing to exclude",
+			map1:     MapAny{"key1": "value1"},
+			map2:     MapAny{},
+			expected: MapAny{"key1": "value1"},
+		},
+		{
+			name:     "exclude keys",
+			map1:     MapAny{"key1": "value1", "key2": "value2", "key3": MapAny{"a": "b"}},
+			map2:     MapAny{"key2": nil, "key3": "x", "key4": nil},
+			expected: MapAny{"key1": "value1"},
+		},
+		{
+			name:     "exclude nested",
+			map1:     MapAny{"auth": MapAny{"user": "foo", "password": "bar"}, "name": "baz"},
+			map2:     MapAny{"auth": MapAny{"password": nil}},
+			expected: MapAny{"auth": MapAny{"user": "foo"}, "name": "baz"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ExcludeMaps(tt.map1, tt.map2)
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %v but got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestClearMaps(t *testing.T) {
+	data := MapAny{"auth": MapAny{"password": "bar", "tokens": []any{"a"}}, "key": "value"}
+	expected := MapAny{"auth": MapAny{"password": nil, "tokens": nil}, "key": nil}
+
+	if result := ClearMaps(data); !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v but got %v", expected, result)
+	}
+}
+
+func TestRedactKeys(t *testing.T) {
+	testCases := []struct {
+		name       string
+		data       MapAny
+		keys       []string
+		expected   MapAny
+		wantValues MapAny
+	}{
+		{
+			name:       "no keys",
+			data:       MapAny{"token": "secret"},
+			keys:       []string{},
+			expected:   MapAny{"token": "secret"},
+			wantValues: MapAny{},
+		},
+		{
+			name:       "redact keys",
+			data:       MapAny{"token": "secret", "auth": MapAny{"user": "foo", "password": "bar"}},
+			keys:       []string{"token", "/auth/password/", "missing", "auth/user/x"},
+			expected:   MapAny{"token": RedactedValue, "auth": MapAny{"user": "foo", "password": RedactedValue}},
+			wantValues: MapAny{"token": "secret", "auth/password": "bar"},
+		},
+		{
+			name:       "redact array elements",
+			data:       MapAny{"keys": []any{MapAny{"id": "1", "key": "a"}, MapAny{"id": "2", "key": "b"}}},
+			keys:       []string{"keys/*/key"},
+			expected:   MapAny{"keys": []any{MapAny{"id": "1", "key": RedactedValue}, MapAny{"id": "2", "key": RedactedValue}}},
+			wantValues: MapAny{"keys/0/key": "a", "keys/1/key": "b"},
+		},
+		{
+			name:       "redact array index",
+			data:       MapAny{"tokens": []any{"a", "b"}},
+			keys:       []string{"tokens/1"},
+			expected:   MapAny{"tokens": []any{"a", RedactedValue}},
+			wantValues: MapAny{"tokens/1": "b"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			result, values := RedactKeys(tt.data, tt.keys)
+
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.wantValues, values)
+		})
+	}
+}
+
+func TestRedactJSONString(t *testing.T) {
+	testCases := []struct {
+		name     string
+		data     string
+		keys     []string
+		expected string
+	}{
+		{
+			name:     "no keys",
+			data:     `<user><token>secret</token></user>`,
+			keys:     nil,
+			expected: `<user><token>secret</token></user>`,
+		},
+		{
+			name:     "redact object",
+			data:     `{"token":"secret","id":12345678901234567890}`,
+			keys:     []string{"token"},
+			expected: `{"id":12345678901234567890,"token":"(redacted)"}`,
+		},
+		{
+			name:     "redact array",
+			data:     `[{"id":1,"token":"a"},{"id":2,"token":"b"}]`,
+			keys:     []string{"token"},
+			expected: `[{"id":1,"token":"(redacted)"},{"id":2,"token":"(redacted)"}]`,
+		},
+		{
+			name:     "redact invalid json",
+			data:     `<user><token>secret</token></user>`,
+			keys:     []string{"token"},
+			expected: RedactedValue,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, RedactJSONString(tt.data, tt.keys))
+		})
+	}
+}
+
 func TestFilterJSONString(t *testing.T) {
 	testCase := []struct {
 		name     string